@@ -0,0 +1,47 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Report the trap regions, dead ends and chokepoints of a map",
+	Long: `Provide a sample .txt file (arg[0]) with cities. Aliensim will print how aliens can move around the map without
+simulating it: its components, the regions aliens can enter but never leave, the cities where aliens are trapped and
+the cities and roads that split the map when destroyed.`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		if !types.StringInSlice(format, types.ReportFormats) {
+			log.Fatalf("Invalid format %s, needs to be one from %v", format, types.ReportFormats)
+		}
+		filePath := args[0]
+		file, err := os.Open(filePath)
+		if err != nil {
+			log.Fatalf("Could not open map %v", err)
+		}
+		defer file.Close()
+		options := mapOptionsFromFlags(cmd)
+		if options.Format == "" {
+			options.Format = types.MapFormatForPath(filePath)
+		}
+		mapObj, err := types.ReadMap(file, options)
+		if err != nil {
+			log.Fatalf("Could not read map %v", err)
+		}
+		if err := types.AnalyzeMap(mapObj).Write(os.Stdout, format); err != nil {
+			log.Fatalf("Could not write analysis %v", err)
+		}
+	},
+}
+
+func initAnalyze() {
+	addMapFlags(analyzeCmd)
+	analyzeCmd.Flags().String("format", "text", fmt.Sprintf("Format of the analysis, one of %v", types.ReportFormats))
+	rootCmd.AddCommand(analyzeCmd)
+}
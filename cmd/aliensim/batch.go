@@ -0,0 +1,43 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim"
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Run many simulations over one map and aggregate their results",
+	Long: `Provide a sample .txt file (arg[0]) with cities and a number of aliens (arg[1]). Aliensim will simulate the invasion
+--runs times with seeds derived from --seed and print the aggregated statistics.`,
+	Args: cobra.MatchAll(cobra.ExactArgs(2), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		config := simulationConfigFromFlags(cmd, args[0], parseNumAliens(args[1]))
+		runs, _ := cmd.Flags().GetInt("runs")
+		workers, _ := cmd.Flags().GetInt("workers")
+		format, _ := cmd.Flags().GetString("format")
+		if !types.StringInSlice(format, types.ReportFormats) {
+			log.Fatalf("Invalid format %s, needs to be one from %v", format, types.ReportFormats)
+		}
+		batch, err := aliemsim.RunBatch(aliemsim.BatchConfig{SimulationConfig: config, Runs: runs, Workers: workers}, aliemsim.OsFS)
+		if err != nil {
+			log.Fatalf("Batch Failed %v", err)
+			os.Exit(1)
+
+		}
+		if err := batch.Write(os.Stdout, format); err != nil {
+			log.Fatalf("Could not write report %v", err)
+		}
+	},
+}
+
+func initBatch() {
+	addSimulationFlags(batchCmd)
+	batchCmd.Flags().Int("runs", 100, "Number of simulations to run")
+	batchCmd.Flags().Int("workers", 0, "Number of simulations running at the same time, 0 uses one per CPU")
+	batchCmd.Flags().String("format", "text", "Format of the aggregated report, one of json|yaml|text")
+	rootCmd.AddCommand(batchCmd)
+}
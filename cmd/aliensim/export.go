@@ -0,0 +1,51 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim"
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"fmt"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write a map on another format, like a Graphviz DOT or GraphML graph",
+	Long: `Provide a sample .txt file (arg[0]) with cities. Aliensim will write the map on --format. With a number of
+aliens (arg[1]) the map is invaded first, and graph formats draw destroyed cities and roads as gray ghosts.`,
+	Args: cobra.MatchAll(cobra.RangeArgs(1, 2), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		numAliens := 0
+		if len(args) == 2 {
+			numAliens = parseNumAliens(args[1])
+		}
+		config := aliemsim.ExportConfig{
+			SimulationConfig: simulationConfigFromFlags(cmd, args[0], numAliens),
+			Invade:           len(args) == 2,
+		}
+		config.Format, _ = cmd.Flags().GetString("format")
+		config.Out, _ = cmd.Flags().GetString("out")
+		config.KeepIsolatedCities, _ = cmd.Flags().GetBool("keep-isolated")
+		if config.Format != "" {
+			if _, err := types.GetMapFormat(config.Format); err != nil {
+				log.Fatalf("%v", err)
+			}
+		} else if config.Out == "-" {
+			config.Format = "dot"
+		}
+		if err := aliemsim.Export(config, aliemsim.OsFS); err != nil {
+			log.Fatalf("Export Failed %v", err)
+			os.Exit(1)
+
+		}
+	},
+}
+
+func initExport() {
+	addSimulationFlags(exportCmd)
+	exportCmd.Flags().String("format", "", fmt.Sprintf("Format of the output, one of %v. Uses the --out extension when empty, or dot for stdout", types.MapFormatNames()))
+	exportCmd.Flags().String("out", "-", "Write the map to this file ('-' for stdout)")
+	exportCmd.Flags().Bool("keep-isolated", false, "Keep cities without roads on the text format as lines with only the city name")
+	rootCmd.AddCommand(exportCmd)
+}
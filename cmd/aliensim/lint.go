@@ -0,0 +1,60 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// lintExitIOError is the exit code of lint when the map cannot be read.
+const lintExitIOError = 3
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check a map file and report every problem found",
+	Long: `Provide a sample .txt file (arg[0]) with cities. Aliensim will print every problem of the map as file:line:column.
+Maps on structured formats are only checked by loading them. Exits with 0 when the map is clean, 1 when there are only warnings, 2 when there are errors and 3 when the file
+cannot be read.`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(lintMapFile(cmd, args[0]))
+	},
+}
+
+// lintMapFile prints the problems of the map file and returns the exit code of lint. The file is closed before
+// returning, so the caller can exit right away.
+func lintMapFile(cmd *cobra.Command, filePath string) int {
+	file, err := os.Open(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not open map %v\n", err)
+		return lintExitIOError
+	}
+	defer file.Close()
+	options := mapOptionsFromFlags(cmd)
+	if options.Format == "" {
+		options.Format = types.MapFormatForPath(filePath)
+	}
+	if options.Format != types.TextFormat {
+		// structured formats are only checked by loading them
+		if _, err := types.ReadMap(file, options); err != nil {
+			fmt.Printf("%s: %s: %v\n", filePath, types.SeverityError, err)
+			return int(types.SeverityError)
+		}
+		return 0
+	}
+	diagnostics, err := types.LintMap(file, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read map %v\n", err)
+		return lintExitIOError
+	}
+	for _, d := range diagnostics {
+		fmt.Println(d.Format(filePath))
+	}
+	return int(types.MaxSeverity(diagnostics))
+}
+
+func initLint() {
+	addMapFlags(lintCmd)
+	rootCmd.AddCommand(lintCmd)
+}
@@ -2,11 +2,13 @@ package aliensim
 
 import (
 	"alien-invasion-simulator/pkg/aliemsim"
+	"alien-invasion-simulator/pkg/aliemsim/types"
 	"fmt"
 	"github.com/spf13/cobra"
 	"log"
 	"os"
 	"strconv"
+	"time"
 )
 
 var rootCmd = &cobra.Command{
@@ -14,27 +16,175 @@ var rootCmd = &cobra.Command{
 	Short: "aliensim - a simple CLI to simulate alien invasions",
 	Long:  `Provide a sample .txt file (arg[0]) with cities and a number of aliens (arg[1]). Aliensim will simulate the invasion.`,
 	Args:  cobra.MatchAll(cobra.ExactArgs(2), cobra.OnlyValidArgs),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if noTimestamps, _ := cmd.Flags().GetBool("no-timestamps"); noTimestamps {
+			log.SetFlags(0)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		filePath := args[0]
-		numAliens, err := strconv.Atoi(args[1])
-
-		if err != nil {
-			log.Fatalf("Invalid number of aliens provided: %v", args[1])
-			os.Exit(1)
-
+		config := simulationConfigFromFlags(cmd, args[0], parseNumAliens(args[1]))
+		eventsOut, _ := cmd.Flags().GetString("events-out")
+		outMap, _ := cmd.Flags().GetString("out-map")
+		outFormat, _ := cmd.Flags().GetString("out-format")
+		if outFormat != "" {
+			if _, err := types.GetMapFormat(outFormat); err != nil {
+				log.Fatalf("%v", err)
+			}
+		}
+		config.OutMapFormat = outFormat
+		keepIsolated, _ := cmd.Flags().GetBool("keep-isolated")
+		config.EventsOut = eventsOut
+		config.OutMap = outMap
+		config.KeepIsolatedCities = keepIsolated
+		reportFormat, _ := cmd.Flags().GetString("report")
+		if reportFormat != "" && !types.StringInSlice(reportFormat, types.ReportFormats) {
+			log.Fatalf("Invalid report format %s, needs to be one from %v", reportFormat, types.ReportFormats)
 		}
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		err = aliemsim.StartSimulation(filePath, numAliens, aliemsim.OsFS, 10000, verbose)
+		report, err := aliemsim.StartSimulation(config, aliemsim.OsFS)
 		if err != nil {
 			log.Fatalf("Simulation Failed %v", err)
 			os.Exit(1)
 
 		}
+		if reportFormat != "" {
+			if err := report.Write(os.Stdout, reportFormat); err != nil {
+				log.Fatalf("Could not write report %v", err)
+			}
+		}
 	},
 }
 
+// parseNumAliens parses the number of aliens arg. Exits on invalid values.
+func parseNumAliens(arg string) int {
+	numAliens, err := strconv.Atoi(arg)
+
+	if err != nil {
+		log.Fatalf("Invalid number of aliens provided: %v", arg)
+		os.Exit(1)
+
+	}
+	return numAliens
+}
+
+// simulationConfigFromFlags builds the config of a simulation over the map file from the simulation flags.
+// Exits on invalid values.
+func simulationConfigFromFlags(cmd *cobra.Command, filePath string, numAliens int) aliemsim.SimulationConfig {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UTC().UnixNano()
+	}
+	config := aliemsim.SimulationConfig{
+		FilePath:      filePath,
+		NumAliens:     numAliens,
+		MaxIterations: aliemsim.DefaultMaxIterations,
+		Verbose:       verbose,
+		Seed:          seed,
+		MapOptions:    mapOptionsFromFlags(cmd),
+	}
+	collisionSpec, _ := cmd.Flags().GetString("collision")
+	collision, err := types.ParseCollisionPolicy(collisionSpec)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.Collision = collision
+	strategySpec, _ := cmd.Flags().GetString("strategy")
+	strategies, err := types.ParseStrategyMix(strategySpec)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.Strategies = strategies
+	tickModeName, _ := cmd.Flags().GetString("tick-mode")
+	tickMode, err := types.ParseTickMode(tickModeName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.TickMode = tickMode
+	spawnSpec, _ := cmd.Flags().GetString("spawn")
+	spawn, err := aliemsim.LoadSpawnStrategy(spawnSpec, aliemsim.OsFS)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.Spawn = spawn
+	spawnCollisionName, _ := cmd.Flags().GetString("spawn-collision")
+	spawnCollision, err := types.ParseSpawnCollision(spawnCollisionName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	config.SpawnCollision = spawnCollision
+	config.TrackOccupancy, _ = cmd.Flags().GetBool("track-occupancy")
+	return config
+}
+
+// mapOptionsFromFlags builds the options to load the map from the map flags.
+func mapOptionsFromFlags(cmd *cobra.Command) types.MapOptions {
+	symmetric, _ := cmd.Flags().GetBool("symmetric")
+	maxLineLength, _ := cmd.Flags().GetInt("max-line-length")
+	comments, _ := cmd.Flags().GetBool("comments")
+	lenient, _ := cmd.Flags().GetBool("lenient")
+	format, _ := cmd.Flags().GetString("map-format")
+	if format != "" {
+		if _, err := types.GetMapFormat(format); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	var directions *types.DirectionSet
+	if spec, _ := cmd.Flags().GetString("directions"); spec != "" {
+		var err error
+		if directions, err = types.ParseDirectionSet(spec); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	return types.MapOptions{
+		Directions:    directions,
+		Format:        format,
+		Symmetric:     symmetric,
+		MaxLineLength: maxLineLength,
+		Comments:      comments,
+		Lenient:       lenient,
+		OnError: func(err *types.ParseError) {
+			log.Printf("Skipping invalid map input on %v", err)
+		},
+	}
+}
+
+// addMapFlags registers the flags that change how the map is loaded.
+func addMapFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("symmetric", false, "Add the road back of every road on the opposite direction, so 'Foo north=Bar' also creates 'Bar south=Foo'")
+	cmd.Flags().Int("max-line-length", types.DefaultMaxLineLength, "Longest line accepted on the map file in bytes")
+	cmd.Flags().Bool("comments", false, "Skip blank lines and everything after a '#' on the map file")
+	cmd.Flags().Bool("lenient", false, "Skip invalid lines and roads of the map file logging them instead of failing")
+	cmd.Flags().String("map-format", "", fmt.Sprintf("Format of the map file, one of %v. Uses the file extension when empty, and text for unknown extensions", types.MapFormatNames()))
+	cmd.Flags().String("directions", "", fmt.Sprintf("Directions roads can take, as a list of sets from %v and labels with an optional inverse like 'port:starboard'. Overrides the '%s' header of the map, and uses compass when both are missing", types.DirectionSetNames(), types.DirectionsHeader))
+}
+
+// addSimulationFlags registers the flags that change how aliens behave and how the map is loaded.
+func addSimulationFlags(cmd *cobra.Command) {
+	addMapFlags(cmd)
+	cmd.Flags().String("collision", "threshold:2", "What happens when aliens meet: threshold:N destroys the city with N aliens, fight leaves one survivor and last-standing fights until one alien is left")
+	cmd.Flags().String("strategy", "random-walk", "How aliens move, one of random-walk|always-move|weighted:dir=w;...|nearest|avoid-occupied|seek-hub|stay-put, or a mix by percentage like random-walk@70,seek-hub@30")
+	cmd.Flags().Bool("track-occupancy", false, "Count aliens on the city they landed on, and stop counting them on a city once they leave it")
+	cmd.Flags().String("tick-mode", "sequential", "How the moves of an iteration are resolved: sequential moves aliens one at a time, synchronous moves them all together")
+	cmd.Flags().String("spawn", "uniform", "Where aliens land, one of uniform|one-per-city|degree|clustered[:N or Foo;Bar][:radius]|file:path with 'alien-name city' lines")
+	cmd.Flags().String("spawn-collision", "coexist", "What happens with aliens landing on the same city: coexist, fight applies --collision at landing, relocate moves them to free cities and reject fails")
+}
+
 func Init() {
 	rootCmd.PersistentFlags().Bool("verbose", false, "A print map stats on every iteration")
+	rootCmd.PersistentFlags().Int64("seed", 0, "Seed for the random source, runs with the same seed are reproducible")
+	rootCmd.PersistentFlags().Bool("no-timestamps", false, "Log without timestamps, so runs with the same seed print the same output")
+	rootCmd.Flags().String("events-out", "", "Write simulation events as JSON lines to this file ('-' for stdout)")
+	rootCmd.Flags().String("out-map", "", "Write the surviving map to this file, on the format matching its extension")
+	rootCmd.Flags().String("out-format", "", fmt.Sprintf("Format of --out-map, one of %v", types.MapFormatNames()))
+	rootCmd.Flags().Bool("keep-isolated", false, "Keep surviving cities without roads on --out-map as lines with only the city name")
+	addSimulationFlags(rootCmd)
+	rootCmd.Flags().String("report", "", "Print a final report of the invaded world to stdout, one of json|yaml|text")
+	initBatch()
+	initSweep()
+	initLint()
+	initExport()
+	initRun()
+	initAnalyze()
 }
 func Execute() {
 
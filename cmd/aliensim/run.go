@@ -0,0 +1,47 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim"
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the simulation described by a scenario file",
+	Long: `Provide a scenario .yaml or .json file (arg[0]) with the map, seed, groups of aliens, rules, stop conditions
+and outputs of a run. Paths on the scenario are relative to its file. --seed and --verbose override the scenario.`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		scenario, err := aliemsim.LoadScenario(args[0], aliemsim.OsFS)
+		if err != nil {
+			log.Fatalf("Could not load scenario %v", err)
+		}
+		if cmd.Flags().Changed("seed") {
+			scenario.Seed, _ = cmd.Flags().GetInt64("seed")
+		}
+		if cmd.Flags().Changed("verbose") {
+			scenario.Verbose, _ = cmd.Flags().GetBool("verbose")
+		}
+		reportFormat, _ := cmd.Flags().GetString("report")
+		if reportFormat != "" && !types.StringInSlice(reportFormat, types.ReportFormats) {
+			log.Fatalf("Invalid report format %s, needs to be one from %v", reportFormat, types.ReportFormats)
+		}
+		report, err := aliemsim.RunScenario(scenario, aliemsim.OsFS)
+		if err != nil {
+			log.Fatalf("Scenario Failed %v", err)
+		}
+		if reportFormat != "" {
+			if err := report.Write(os.Stdout, reportFormat); err != nil {
+				log.Fatalf("Could not write report %v", err)
+			}
+		}
+	},
+}
+
+func initRun() {
+	runCmd.Flags().String("report", "", "Print a final report of the invaded world to stdout, one of json|yaml|text")
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,64 @@
+package aliensim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim"
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Run batches over one map varying the number of aliens and the rules",
+	Long: `Provide a sample .txt file (arg[0]) with cities. Aliensim will run a batch of --runs simulations for every
+combination of --aliens, --max-iterations and --threshold and print a table of outcome metrics per combination.
+Ranges have the format start..end:step.`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		config := aliemsim.SweepConfig{}
+		config.SimulationConfig = simulationConfigFromFlags(cmd, args[0], 0)
+		config.Runs, _ = cmd.Flags().GetInt("runs")
+		config.Workers, _ = cmd.Flags().GetInt("workers")
+		config.Aliens = rangeFromFlag(cmd, "aliens")
+		config.MaxIterations = rangeFromFlag(cmd, "max-iterations")
+		config.Thresholds = rangeFromFlag(cmd, "threshold")
+		format, _ := cmd.Flags().GetString("format")
+		if !types.StringInSlice(format, types.SweepFormats) {
+			log.Fatalf("Invalid format %s, needs to be one from %v", format, types.SweepFormats)
+		}
+		report, err := aliemsim.RunSweep(config, aliemsim.OsFS)
+		if err != nil {
+			log.Fatalf("Sweep Failed %v", err)
+			os.Exit(1)
+
+		}
+		if err := report.Write(os.Stdout, format); err != nil {
+			log.Fatalf("Could not write report %v", err)
+		}
+	},
+}
+
+// rangeFromFlag parses the range on the flag with the given name, or returns nil when the flag is empty.
+func rangeFromFlag(cmd *cobra.Command, name string) []int {
+	spec, _ := cmd.Flags().GetString(name)
+	if spec == "" {
+		return nil
+	}
+	values, err := types.ParseIntRange(spec)
+	if err != nil {
+		log.Fatalf("Invalid --%s: %v", name, err)
+	}
+	return values
+}
+
+func initSweep() {
+	addSimulationFlags(sweepCmd)
+	sweepCmd.Flags().String("aliens", "1..100:10", "Numbers of aliens to try, as a value or a range start..end:step")
+	sweepCmd.Flags().String("max-iterations", "", "Max moves per alien to try, as a value or a range start..end:step (default 10000)")
+	sweepCmd.Flags().String("threshold", "", "Collision thresholds to try, as a value or a range start..end:step. Overrides --collision")
+	sweepCmd.Flags().Int("runs", 100, "Number of simulations per combination")
+	sweepCmd.Flags().Int("workers", 0, "Number of simulations running at the same time, 0 uses one per CPU")
+	sweepCmd.Flags().String("format", "csv", "Format of the table, one of csv|json")
+	rootCmd.AddCommand(sweepCmd)
+}
@@ -0,0 +1,102 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"errors"
+	"log"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+var ErrorInvalidRuns = errors.New("Invalid number of runs.")
+
+// BatchConfig holds the settings of many simulations over the same map.
+type BatchConfig struct {
+	SimulationConfig
+	// Runs is the number of simulations.
+	Runs int
+	// Workers is the number of simulations running at the same time. Uses the number of CPUs when 0.
+	Workers int
+}
+
+// runSeeds derives the seed of every run from the base seed, so a batch is reproducible and every run can be
+// replayed alone with its own seed.
+func runSeeds(seed int64, runs int) []int64 {
+	rng := rand.New(rand.NewSource(seed))
+	seeds := make([]int64, runs)
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+	return seeds
+}
+
+// RunBatch reads the map once and runs config.Runs silent simulations on a pool of workers, each one on its own clone
+// of the map. Each run gets its own seed derived from config.Seed. Returns the aggregated report of all runs.
+func RunBatch(config BatchConfig, fs FileSystem) (types.BatchReport, error) {
+	if config.Runs <= 0 {
+		return types.BatchReport{}, ErrorInvalidRuns
+	}
+	log.Printf("Starting batch of %d runs with: %d aliens (seed %d)...", config.Runs, config.NumAliens, config.Seed)
+	file, err := fs.Open(config.FilePath)
+	if err != nil {
+		return types.BatchReport{}, err
+	}
+	defer file.Close()
+	mapObj, err := newMapFromReader(file, config.mapOptions())
+	if err != nil {
+		return types.BatchReport{}, err
+	}
+	return runBatch(mapObj, config)
+}
+
+// runBatch runs the simulations of a batch over clones of the map and aggregates their reports. The map is not
+// changed.
+func runBatch(mapObj *types.Map[types.City, types.Direction], config BatchConfig) (types.BatchReport, error) {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > config.Runs {
+		workers = config.Runs
+	}
+	seeds := runSeeds(config.Seed, config.Runs)
+	reports := make([]types.Report, config.Runs)
+	errs := make([]error, config.Runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i], errs[i] = runSilent(mapObj.Clone(), config.SimulationConfig, seeds[i])
+			}
+		}()
+	}
+	for i := 0; i < config.Runs; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return types.BatchReport{}, err
+		}
+	}
+	return types.NewBatchReport(config.Seed, reports), nil
+}
+
+// runSilent runs one simulation over the map with the given seed without logging any event.
+func runSilent(mapObj *types.Map[types.City, types.Direction], config SimulationConfig, seed int64) (types.Report, error) {
+	config.Seed = seed
+	config.Verbose = false
+	sim, err := newSimulator(mapObj, config)
+	if err != nil {
+		return types.Report{}, err
+	}
+	sim.Sink = nil
+	err = sim.SimulateInvasion()
+	return sim.Report(), err
+}
@@ -0,0 +1,85 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, &BatchTestSuite{})
+}
+
+const sampleMap = "../../sampleMapFiles/cities1.txt"
+
+func (s *BatchTestSuite) TestRunSeeds() {
+	seeds := runSeeds(3, 5)
+	s.Equal(seeds, runSeeds(3, 5))
+	distinct := map[int64]bool{}
+	for _, seed := range seeds {
+		distinct[seed] = true
+	}
+	s.Equal(5, len(distinct))
+}
+
+// TestRunBatchWorkers tests that the number of workers does not change the result
+func (s *BatchTestSuite) TestRunBatchWorkers() {
+	config := BatchConfig{
+		SimulationConfig: SimulationConfig{FilePath: sampleMap, NumAliens: 4, MaxIterations: 100, Seed: 11},
+		Runs:             40,
+		Workers:          1,
+	}
+	single, err := RunBatch(config, OsFS)
+	s.Nil(err)
+	config.Workers = 4
+	parallel, err := RunBatch(config, OsFS)
+	s.Nil(err)
+	s.Equal(single, parallel)
+	s.Equal(40, single.Runs)
+	s.Equal(5, len(single.Cities))
+	total := 0
+	for _, count := range single.StopReasons {
+		total += count
+	}
+	s.Equal(40, total)
+}
+
+// TestRunBatchSeed tests that every run of the batch can be replayed alone with its derived seed
+func (s *BatchTestSuite) TestRunBatchSeed() {
+	config := BatchConfig{
+		SimulationConfig: SimulationConfig{FilePath: sampleMap, NumAliens: 4, MaxIterations: 100, Seed: 5},
+		Runs:             1,
+	}
+	batch, err := RunBatch(config, OsFS)
+	s.Nil(err)
+	report, err := StartSimulation(SimulationConfig{FilePath: sampleMap, NumAliens: 4, MaxIterations: 100, Seed: runSeeds(5, 1)[0]}, OsFS)
+	s.Nil(err)
+	s.Equal(report.Iterations, batch.Iterations.Max)
+	s.Equal(1, batch.StopReasons[report.StopReason])
+}
+
+func (s *BatchTestSuite) TestRunBatchErrors() {
+	_, err := RunBatch(BatchConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Runs: 0}, OsFS)
+	s.ErrorIs(err, ErrorInvalidRuns)
+	_, err = RunBatch(BatchConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Runs: 2}, fakeFSErr{})
+	s.ErrorIs(err, FileOpenErrMock)
+}
+
+// TestRunBatchKeepsMap tests that the runs of a batch invade clones of the map
+func (s *BatchTestSuite) TestRunBatchKeepsMap() {
+	file, err := OsFS.Open(sampleMap)
+	s.Nil(err)
+	defer file.Close()
+	mapObj, err := types.ReadMap(file, types.MapOptions{})
+	s.Nil(err)
+	before := mapObj.ToString()
+	config := BatchConfig{SimulationConfig: SimulationConfig{NumAliens: 10, MaxIterations: 100, Seed: 3}, Runs: 5}
+	batch, err := runBatch(mapObj, config)
+	s.Nil(err)
+	s.Less(batch.CitySurvivalRate, 1.0)
+	s.Equal(before, mapObj.ToString())
+}
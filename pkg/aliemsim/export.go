@@ -0,0 +1,49 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+)
+
+// ExportConfig holds the settings of a map export.
+type ExportConfig struct {
+	SimulationConfig
+	// Invade runs the simulation before writing the map, so graph formats draw destroyed cities and roads as ghosts.
+	Invade bool
+	// Format is the map format of Out. Uses the format matching the Out extension when empty.
+	Format string
+	// Out is the path where the map is written. Use "-" for stdout.
+	Out string
+}
+
+// Export writes the map of config.FilePath into config.Out, after invading it when config.Invade is set.
+func Export(config ExportConfig, fs FileSystem) error {
+	file, err := fs.Open(config.FilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	mapObj, err := newMapFromReader(file, config.mapOptions())
+	if err != nil {
+		return err
+	}
+	options := types.ExportOptions{KeepIsolatedCities: config.KeepIsolatedCities}
+	if config.Invade {
+		options.Before = mapObj
+		mapObj = mapObj.Clone()
+		sim, err := newSimulator(mapObj, config.SimulationConfig)
+		if err != nil {
+			return err
+		}
+		if !config.Verbose {
+			sim.Sink = nil
+		}
+		if err := sim.SimulateInvasion(); err != nil {
+			return err
+		}
+	}
+	format := config.Format
+	if format == "" {
+		format = types.MapFormatForPath(config.Out)
+	}
+	return writeMap(mapObj, config.Out, format, options, fs)
+}
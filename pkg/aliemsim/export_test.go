@@ -0,0 +1,53 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, &ExportTestSuite{})
+}
+
+func (s *ExportTestSuite) TestExport() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "world.dot")
+	s.Nil(Export(ExportConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Out: out}, OsFS))
+	content, err := os.ReadFile(out)
+	s.Nil(err)
+	s.Contains(string(content), `"Foo" -> "Bar" [label="north"];`)
+	s.NotContains(string(content), "dashed")
+
+	// the first 2 aliens meeting destroy one of the 2 cities of the map
+	mix, _ := types.ParseStrategyMix("always-move")
+	pair := filepath.Join(dir, "pair.txt")
+	s.Nil(os.WriteFile(pair, []byte("Foo north=Bar\nBar south=Foo\n"), 0644))
+	invaded := filepath.Join(dir, "invaded.gv")
+	config := ExportConfig{
+		SimulationConfig: SimulationConfig{FilePath: pair, NumAliens: 20, MaxIterations: 100, Seed: 1, Strategies: mix},
+		Invade:           true,
+		Out:              invaded,
+	}
+	s.Nil(Export(config, OsFS))
+	content, err = os.ReadFile(invaded)
+	s.Nil(err)
+	s.Equal(`digraph world {
+  "Bar" [style=dashed, color=gray, fontcolor=gray];
+  "Foo";
+  "Bar" -> "Foo" [label="south", style=dashed, color=gray, fontcolor=gray];
+  "Foo" -> "Bar" [label="north", style=dashed, color=gray, fontcolor=gray];
+}
+`, string(content))
+}
+
+func (s *ExportTestSuite) TestExportErrors() {
+	s.ErrorIs(Export(ExportConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Out: "-", Format: "xml"}, OsFS), types.ErrorUnknownMapFormat)
+	s.ErrorIs(Export(ExportConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Out: "out.dot"}, fakeFSErr{}), FileOpenErrMock)
+}
@@ -15,6 +15,21 @@ type fakeFSErr struct{}
 // fakeFS implements FileSystem forcing with no errors
 type fakeFS struct{}
 
+// fakeFSCreateErr implements FileSystem forcing create error
+type fakeFSCreateErr struct {
+	fakeFS
+}
+
+// fakeFSCloseErr implements FileSystem on top of another one forcing close error on created files
+type fakeFSCloseErr struct {
+	FileSystem
+}
+
+// closeErrFile is a created file that cannot be closed, like when its last write cannot be flushed
+type closeErrFile struct {
+	io.WriteCloser
+}
+
 var OsFS FileSystem = osFS{}
 
 type MockedFile struct {
@@ -26,10 +41,12 @@ func (f MockedFile) Read(p []byte) (int, error)                   { return 1, ni
 func (f MockedFile) ReadAt(p []byte, off int64) (int, error)      { return 0, nil }
 func (f MockedFile) Seek(offset int64, whence int) (int64, error) { return 0, nil }
 func (f MockedFile) Stat() (os.FileInfo, error)                   { return f, nil }
+func (f MockedFile) Write(p []byte) (int, error)                  { return len(p), nil }
 
 type FileSystem interface {
 	Open(name string) (file, error)
 	Stat(name string) (os.FileInfo, error)
+	Create(name string) (io.WriteCloser, error)
 }
 
 type file interface {
@@ -41,9 +58,12 @@ type file interface {
 }
 
 var FileOpenErrMock = errors.New("error opening file")
+var FileCreateErrMock = errors.New("error creating file")
+var FileCloseErrMock = errors.New("error closing file")
 
-func (osFS) Open(name string) (file, error)        { return os.Open(name) }
-func (osFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
+func (osFS) Open(name string) (file, error)             { return os.Open(name) }
+func (osFS) Stat(name string) (os.FileInfo, error)      { return os.Stat(name) }
+func (osFS) Create(name string) (io.WriteCloser, error) { return os.Create(name) }
 
 func (fakeFSErr) Open(name string) (file, error) {
 	f := MockedFile{}
@@ -53,6 +73,10 @@ func (fakeFSErr) Stat(name string) (os.FileInfo, error) {
 	f := MockedFile{}
 	return f.Stat()
 }
+func (fakeFSErr) Create(name string) (io.WriteCloser, error) {
+	f := MockedFile{}
+	return f, FileCreateErrMock
+}
 
 func (fakeFS) Open(name string) (file, error) {
 	f := MockedFile{}
@@ -62,3 +86,25 @@ func (fakeFS) Stat(name string) (os.FileInfo, error) {
 	f := MockedFile{}
 	return f.Stat()
 }
+func (fakeFS) Create(name string) (io.WriteCloser, error) {
+	f := MockedFile{}
+	return f, nil
+}
+
+func (fakeFSCreateErr) Create(name string) (io.WriteCloser, error) {
+	f := MockedFile{}
+	return f, FileCreateErrMock
+}
+
+func (fs fakeFSCloseErr) Create(name string) (io.WriteCloser, error) {
+	f, err := fs.FileSystem.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return closeErrFile{f}, nil
+}
+
+func (f closeErrFile) Close() error {
+	f.WriteCloser.Close()
+	return FileCloseErrMock
+}
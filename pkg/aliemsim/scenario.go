@@ -0,0 +1,273 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+var ErrorInvalidScenario = errors.New("Invalid scenario.")
+
+// Scenario is the whole configuration of a run, read from a YAML or JSON file so experiments can be checked in.
+type Scenario struct {
+	Name string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Map  ScenarioMap `json:"map" yaml:"map"`
+	// Seed makes the run reproducible. Scenarios without seed use 0.
+	Seed int64 `json:"seed" yaml:"seed"`
+	// MaxIterations is the max number of moves per alien. Uses DefaultMaxIterations when 0.
+	MaxIterations int    `json:"max_iterations,omitempty" yaml:"max_iterations,omitempty"`
+	Verbose       bool   `json:"verbose,omitempty" yaml:"verbose,omitempty"`
+	TickMode      string `json:"tick_mode,omitempty" yaml:"tick_mode,omitempty"`
+	// Collision is a collision policy spec, like 'threshold:2' or 'fight'.
+	Collision string `json:"collision,omitempty" yaml:"collision,omitempty"`
+	// TrackOccupancy counts the aliens on a city from landing until they leave it.
+	TrackOccupancy bool `json:"track_occupancy,omitempty" yaml:"track_occupancy,omitempty"`
+	// Spawn is a spawn strategy spec, like 'one-per-city' or 'file:landing.txt'.
+	Spawn          string               `json:"spawn,omitempty" yaml:"spawn,omitempty"`
+	SpawnCollision string               `json:"spawn_collision,omitempty" yaml:"spawn_collision,omitempty"`
+	Aliens         []ScenarioAliens     `json:"aliens" yaml:"aliens"`
+	Stop           types.StopConditions `json:"stop,omitempty" yaml:"stop,omitempty"`
+	Outputs        ScenarioOutputs      `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// ScenarioMap is the map of a scenario and how it is loaded.
+type ScenarioMap struct {
+	Path string `json:"path" yaml:"path"`
+	// Format is the name of the map format. Uses the format matching the Path extension when empty.
+	Format        string `json:"format,omitempty" yaml:"format,omitempty"`
+	Symmetric     bool   `json:"symmetric,omitempty" yaml:"symmetric,omitempty"`
+	Comments      bool   `json:"comments,omitempty" yaml:"comments,omitempty"`
+	Lenient       bool   `json:"lenient,omitempty" yaml:"lenient,omitempty"`
+	MaxLineLength int    `json:"max_line_length,omitempty" yaml:"max_line_length,omitempty"`
+	// Directions is a direction set spec overriding the directions of the map.
+	Directions string `json:"directions,omitempty" yaml:"directions,omitempty"`
+}
+
+// ScenarioAliens is a group of aliens moving with the same strategy. Aliens without strategy do a random walk.
+type ScenarioAliens struct {
+	Count    int    `json:"count" yaml:"count"`
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+}
+
+// ScenarioOutputs are the files written by a scenario run. Empty paths are skipped and "-" is stdout.
+type ScenarioOutputs struct {
+	Events string `json:"events,omitempty" yaml:"events,omitempty"`
+	Map    string `json:"map,omitempty" yaml:"map,omitempty"`
+	// MapFormat is the format of Map. Uses the format matching the Map extension when empty.
+	MapFormat    string `json:"map_format,omitempty" yaml:"map_format,omitempty"`
+	KeepIsolated bool   `json:"keep_isolated,omitempty" yaml:"keep_isolated,omitempty"`
+	Report       string `json:"report,omitempty" yaml:"report,omitempty"`
+	// ReportFormat is one of types.ReportFormats. Uses json or yaml following the Report extension, and text
+	// otherwise.
+	ReportFormat string `json:"report_format,omitempty" yaml:"report_format,omitempty"`
+}
+
+// ReadScenario reads a scenario on the json or yaml format. Unknown fields are an error.
+func ReadScenario(reader io.Reader, format string) (Scenario, error) {
+	scenario := Scenario{}
+	var err error
+	switch format {
+	case "json":
+		decoder := json.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&scenario)
+	case "yaml":
+		decoder := yaml.NewDecoder(reader)
+		decoder.KnownFields(true)
+		err = decoder.Decode(&scenario)
+	default:
+		return Scenario{}, fmt.Errorf("%w Format '%s' needs to be json or yaml", ErrorInvalidScenario, format)
+	}
+	if err != nil {
+		return Scenario{}, fmt.Errorf("%w %v", ErrorInvalidScenario, err)
+	}
+	return scenario, nil
+}
+
+// LoadScenario reads the scenario file at path on the format matching its extension, yaml unless it is '.json'.
+// Relative paths of the scenario are made relative to the directory of the file.
+func LoadScenario(path string, fs FileSystem) (Scenario, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	defer file.Close()
+	format := "yaml"
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		format = "json"
+	}
+	scenario, err := ReadScenario(file, format)
+	if err != nil {
+		return Scenario{}, err
+	}
+	dir := filepath.Dir(path)
+	scenario.Map.Path = resolvePath(dir, scenario.Map.Path)
+	if strings.HasPrefix(scenario.Spawn, "file:") {
+		scenario.Spawn = "file:" + resolvePath(dir, strings.TrimPrefix(scenario.Spawn, "file:"))
+	}
+	scenario.Outputs.Events = resolvePath(dir, scenario.Outputs.Events)
+	scenario.Outputs.Map = resolvePath(dir, scenario.Outputs.Map)
+	scenario.Outputs.Report = resolvePath(dir, scenario.Outputs.Report)
+	return scenario, nil
+}
+
+// resolvePath joins relative paths to dir. Empty paths, "-" and absolute paths are left as they are.
+func resolvePath(dir, path string) string {
+	if path == "" || path == "-" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// Config builds the config of the scenario simulation. Placements of a 'file:' spawn are read from fs.
+func (sc Scenario) Config(fs FileSystem) (SimulationConfig, error) {
+	if sc.Map.Path == "" {
+		return SimulationConfig{}, fmt.Errorf("%w The map needs a path", ErrorInvalidScenario)
+	}
+	if sc.MaxIterations < 0 {
+		return SimulationConfig{}, fmt.Errorf("%w max_iterations cannot be negative, got %d", ErrorInvalidScenario, sc.MaxIterations)
+	}
+	config := SimulationConfig{
+		FilePath:           sc.Map.Path,
+		MaxIterations:      sc.MaxIterations,
+		Verbose:            sc.Verbose,
+		Seed:               sc.Seed,
+		EventsOut:          sc.Outputs.Events,
+		OutMap:             sc.Outputs.Map,
+		OutMapFormat:       sc.Outputs.MapFormat,
+		KeepIsolatedCities: sc.Outputs.KeepIsolated,
+		Stop:               sc.Stop,
+		TrackOccupancy:     sc.TrackOccupancy,
+		MapOptions: types.MapOptions{
+			Format:        sc.Map.Format,
+			Symmetric:     sc.Map.Symmetric,
+			Comments:      sc.Map.Comments,
+			Lenient:       sc.Map.Lenient,
+			MaxLineLength: sc.Map.MaxLineLength,
+			OnError: func(err *types.ParseError) {
+				log.Printf("Skipping invalid map input on %v", err)
+			},
+		},
+	}
+	if config.MaxIterations == 0 {
+		config.MaxIterations = DefaultMaxIterations
+	}
+	if sc.Map.Format != "" {
+		if _, err := types.GetMapFormat(sc.Map.Format); err != nil {
+			return SimulationConfig{}, err
+		}
+	}
+	if sc.Outputs.MapFormat != "" {
+		if _, err := types.GetMapFormat(sc.Outputs.MapFormat); err != nil {
+			return SimulationConfig{}, err
+		}
+	}
+	if sc.Map.Directions != "" {
+		directions, err := types.ParseDirectionSet(sc.Map.Directions)
+		if err != nil {
+			return SimulationConfig{}, err
+		}
+		config.MapOptions.Directions = directions
+	}
+	if err := sc.Stop.Validate(); err != nil {
+		return SimulationConfig{}, err
+	}
+	if sc.TickMode != "" {
+		tickMode, err := types.ParseTickMode(sc.TickMode)
+		if err != nil {
+			return SimulationConfig{}, err
+		}
+		config.TickMode = tickMode
+	}
+	if sc.Collision != "" {
+		collision, err := types.ParseCollisionPolicy(sc.Collision)
+		if err != nil {
+			return SimulationConfig{}, err
+		}
+		config.Collision = collision
+	}
+	spawn, err := LoadSpawnStrategy(sc.Spawn, fs)
+	if err != nil {
+		return SimulationConfig{}, err
+	}
+	config.Spawn = spawn
+	if sc.SpawnCollision != "" {
+		if config.SpawnCollision, err = types.ParseSpawnCollision(sc.SpawnCollision); err != nil {
+			return SimulationConfig{}, err
+		}
+	}
+	if len(sc.Aliens) == 0 {
+		return SimulationConfig{}, fmt.Errorf("%w It needs at least one group of aliens", ErrorInvalidScenario)
+	}
+	config.Groups = []AlienGroup{}
+	for i, aliens := range sc.Aliens {
+		if aliens.Count < 0 {
+			return SimulationConfig{}, fmt.Errorf("%w Group %d cannot have a negative count, got %d", ErrorInvalidScenario, i, aliens.Count)
+		}
+		group := AlienGroup{Count: aliens.Count}
+		if aliens.Strategy != "" {
+			if group.Strategy, err = types.ParseMovementStrategy(aliens.Strategy); err != nil {
+				return SimulationConfig{}, err
+			}
+		}
+		config.Groups = append(config.Groups, group)
+		config.NumAliens += aliens.Count
+	}
+	if _, err := sc.reportFormat(); err != nil {
+		return SimulationConfig{}, err
+	}
+	return config, nil
+}
+
+// reportFormat returns the format of the report output.
+func (sc Scenario) reportFormat() (string, error) {
+	format := sc.Outputs.ReportFormat
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(sc.Outputs.Report)) {
+		case ".json":
+			format = "json"
+		case ".yaml", ".yml":
+			format = "yaml"
+		default:
+			format = "text"
+		}
+	}
+	if !types.StringInSlice(format, types.ReportFormats) {
+		return "", fmt.Errorf("%w Report format '%s' needs to be one from %v", ErrorInvalidScenario, format, types.ReportFormats)
+	}
+	return format, nil
+}
+
+// RunScenario runs the scenario simulation and writes its outputs. Returns the report of the invaded world.
+func RunScenario(scenario Scenario, fs FileSystem) (types.Report, error) {
+	config, err := scenario.Config(fs)
+	if err != nil {
+		return types.Report{}, err
+	}
+	if scenario.Name != "" {
+		log.Printf("Running scenario %s...", scenario.Name)
+	}
+	report, err := StartSimulation(config, fs)
+	if err != nil {
+		return report, err
+	}
+	if scenario.Outputs.Report == "" {
+		return report, nil
+	}
+	format, _ := scenario.reportFormat()
+	out, err := createOutput(scenario.Outputs.Report, fs)
+	if err != nil {
+		return report, err
+	}
+	if err := report.Write(out, format); err != nil {
+		out.Close()
+		return report, err
+	}
+	return report, out.Close()
+}
@@ -0,0 +1,200 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type ScenarioTestSuite struct {
+	suite.Suite
+}
+
+func TestScenarioSuite(t *testing.T) {
+	suite.Run(t, &ScenarioTestSuite{})
+}
+
+const scenarioYAML = `
+name: test
+map:
+  path: cities1.txt
+  symmetric: true
+seed: 7
+max_iterations: 20
+tick_mode: synchronous
+collision: fight
+spawn: one-per-city
+spawn_collision: reject
+aliens:
+  - count: 2
+    strategy: stay-put
+  - count: 1
+stop:
+  aliens_alive: 1
+outputs:
+  events: events.jsonl
+  report: report.yaml
+`
+
+func (s *ScenarioTestSuite) TestReadScenario() {
+	scenario, err := ReadScenario(strings.NewReader(scenarioYAML), "yaml")
+	s.Nil(err)
+	s.Equal(Scenario{
+		Name:           "test",
+		Map:            ScenarioMap{Path: "cities1.txt", Symmetric: true},
+		Seed:           7,
+		MaxIterations:  20,
+		TickMode:       "synchronous",
+		Collision:      "fight",
+		Spawn:          "one-per-city",
+		SpawnCollision: "reject",
+		Aliens:         []ScenarioAliens{{Count: 2, Strategy: "stay-put"}, {Count: 1}},
+		Stop:           types.StopConditions{AliensAlive: 1},
+		Outputs:        ScenarioOutputs{Events: "events.jsonl", Report: "report.yaml"},
+	}, scenario)
+
+	content, err := json.Marshal(scenario)
+	s.Nil(err)
+	fromJSON, err := ReadScenario(strings.NewReader(string(content)), "json")
+	s.Nil(err)
+	s.Equal(scenario, fromJSON)
+
+	vals := []struct {
+		name    string
+		content string
+		format  string
+	}{
+		{name: "unknown yaml field", content: "map:\n  path: x.txt\nalien: 3\n", format: "yaml"},
+		{name: "unknown json field", content: `{"map": {"path": "x.txt"}, "alien": 3}`, format: "json"},
+		{name: "wrong type", content: "seed: soon\n", format: "yaml"},
+		{name: "empty", content: "", format: "yaml"},
+		{name: "unknown format", content: "{}", format: "toml"},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			_, err := ReadScenario(strings.NewReader(val.content), val.format)
+			s.ErrorIs(err, ErrorInvalidScenario)
+		})
+	}
+}
+
+func (s *ScenarioTestSuite) TestScenarioConfig() {
+	scenario, _ := ReadScenario(strings.NewReader(scenarioYAML), "yaml")
+	config, err := scenario.Config(OsFS)
+	s.Nil(err)
+	s.Equal(3, config.NumAliens)
+	s.Equal(20, config.MaxIterations)
+	s.Equal(int64(7), config.Seed)
+	s.Equal(types.TickSynchronous, config.TickMode)
+	s.Equal(types.FightCollision{}, config.Collision)
+	s.Equal(types.OnePerCitySpawn{}, config.Spawn)
+	s.Equal(types.SpawnReject, config.SpawnCollision)
+	s.Equal([]AlienGroup{{Count: 2, Strategy: types.StayPut}, {Count: 1}}, config.Groups)
+	s.True(config.MapOptions.Symmetric)
+	s.Equal("events.jsonl", config.EventsOut)
+
+	scenario.MaxIterations = 0
+	config, _ = scenario.Config(OsFS)
+	s.Equal(DefaultMaxIterations, config.MaxIterations)
+
+	vals := []struct {
+		name   string
+		change func(*Scenario)
+		err    error
+	}{
+		{name: "no map", change: func(sc *Scenario) { sc.Map.Path = "" }, err: ErrorInvalidScenario},
+		{name: "no aliens", change: func(sc *Scenario) { sc.Aliens = nil }, err: ErrorInvalidScenario},
+		{name: "negative count", change: func(sc *Scenario) { sc.Aliens[1].Count = -1 }, err: ErrorInvalidScenario},
+		{name: "negative iterations", change: func(sc *Scenario) { sc.MaxIterations = -1 }, err: ErrorInvalidScenario},
+		{name: "strategy", change: func(sc *Scenario) { sc.Aliens[0].Strategy = "teleport" }, err: types.ErrorInvalidMovementStrategy},
+		{name: "collision", change: func(sc *Scenario) { sc.Collision = "hug" }, err: types.ErrorInvalidCollisionPolicy},
+		{name: "spawn", change: func(sc *Scenario) { sc.Spawn = "everywhere" }, err: types.ErrorInvalidSpawn},
+		{name: "spawn collision", change: func(sc *Scenario) { sc.SpawnCollision = "ignore" }, err: types.ErrorInvalidSpawn},
+		{name: "stop", change: func(sc *Scenario) { sc.Stop.CitiesLeft = -2 }, err: types.ErrorInvalidStopConditions},
+		{name: "map format", change: func(sc *Scenario) { sc.Map.Format = "xml" }, err: types.ErrorUnknownMapFormat},
+		{name: "output map format", change: func(sc *Scenario) { sc.Outputs.MapFormat = "xml" }, err: types.ErrorUnknownMapFormat},
+		{name: "directions", change: func(sc *Scenario) { sc.Map.Directions = "north:" }, err: types.ErrorInvalidDirectionSet},
+		{name: "report format", change: func(sc *Scenario) { sc.Outputs.ReportFormat = "csv" }, err: ErrorInvalidScenario},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			scenario, _ := ReadScenario(strings.NewReader(scenarioYAML), "yaml")
+			val.change(&scenario)
+			_, err := scenario.Config(OsFS)
+			s.ErrorIs(err, val.err)
+		})
+	}
+}
+
+func (s *ScenarioTestSuite) TestReportFormat() {
+	vals := map[string]string{"": "text", "out.json": "json", "out.YML": "yaml", "out.yaml": "yaml", "out.txt": "text"}
+	for path, format := range vals {
+		got, err := Scenario{Outputs: ScenarioOutputs{Report: path}}.reportFormat()
+		s.Nil(err)
+		s.Equal(format, got)
+	}
+	got, _ := Scenario{Outputs: ScenarioOutputs{Report: "out.json", ReportFormat: "yaml"}}.reportFormat()
+	s.Equal("yaml", got)
+}
+
+func (s *ScenarioTestSuite) TestResolvePath() {
+	s.Equal("", resolvePath("dir", ""))
+	s.Equal("-", resolvePath("dir", "-"))
+	s.Equal("/tmp/x", resolvePath("dir", "/tmp/x"))
+	s.Equal(filepath.Join("dir", "x.txt"), resolvePath("dir", "x.txt"))
+}
+
+// TestRunScenario tests loading a scenario with paths relative to its file and writing its outputs
+func (s *ScenarioTestSuite) TestRunScenario() {
+	dir := s.T().TempDir()
+	sampleDir, _ := filepath.Abs("../../sampleMapFiles")
+	content := strings.ReplaceAll(scenarioYAML, "cities1.txt", filepath.Join(sampleDir, "cities1.txt"))
+	s.Nil(os.WriteFile(filepath.Join(dir, "scenario.yml"), []byte(content), 0644))
+
+	scenario, err := LoadScenario(filepath.Join(dir, "scenario.yml"), OsFS)
+	s.Nil(err)
+	s.Equal(filepath.Join(dir, "events.jsonl"), scenario.Outputs.Events)
+	report, err := RunScenario(scenario, OsFS)
+	s.Nil(err)
+	s.Equal(int64(7), report.Seed)
+	s.Len(report.Aliens, 3)
+	for _, alien := range report.Aliens[:2] {
+		s.Zero(alien.NumMovements)
+	}
+
+	written, err := os.ReadFile(filepath.Join(dir, "report.yaml"))
+	s.Nil(err)
+	s.Contains(string(written), "seed: 7")
+	_, err = os.Stat(filepath.Join(dir, "events.jsonl"))
+	s.Nil(err)
+
+	again, err := RunScenario(scenario, OsFS)
+	s.Nil(err)
+	s.Equal(report, again)
+
+	// the report is not written when its file cannot be closed
+	noEvents := scenario
+	noEvents.Outputs.Events = ""
+	_, err = RunScenario(noEvents, fakeFSCloseErr{OsFS})
+	s.ErrorIs(err, FileCloseErrMock)
+
+	_, err = LoadScenario(filepath.Join(dir, "missing.yaml"), OsFS)
+	s.NotNil(err)
+	scenario.Aliens = []ScenarioAliens{{Count: 6}}
+	_, err = RunScenario(scenario, OsFS)
+	s.ErrorIs(err, types.ErrorInvalidSpawn)
+}
+
+// TestLoadScenarioSample tests the scenario shipped with the repo
+func (s *ScenarioTestSuite) TestLoadScenarioSample() {
+	scenario, err := LoadScenario("../../scenarios/hunters.yaml", OsFS)
+	s.Nil(err)
+	s.Equal(filepath.Join("..", "..", "sampleMapFiles", "cities1.txt"), scenario.Map.Path)
+	config, err := scenario.Config(OsFS)
+	s.Nil(err)
+	s.Equal(5, config.NumAliens)
+}
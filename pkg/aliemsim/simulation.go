@@ -3,43 +3,238 @@ package aliemsim
 import (
 	"alien-invasion-simulator/pkg/aliemsim/types"
 	"github.com/goombaio/namegenerator"
+	"io"
 	"log"
-	"time"
+	"math/rand"
+	"os"
+	"strings"
 )
 
-func spawnAliens(numAliens int, mapObj *types.Map[types.City, types.Direction]) []*types.Alien {
+// spawnAliens creates numAliens aliens with random names on random cities. All randomness comes from rng.
+func spawnAliens(numAliens int, mapObj *types.Map[types.City, types.Direction], rng *rand.Rand) []*types.Alien {
+	aliens, _ := placeAliens(numAliens, mapObj, types.UniformSpawn{}, types.SpawnCoexist, rng)
+	return aliens
+}
+
+// placeAliens creates numAliens aliens on the cities chosen by the spawn strategy, and relocates or rejects the
+// aliens landing together following the spawn collision policy. Aliens without a placement name get a random one.
+func placeAliens(numAliens int, mapObj *types.Map[types.City, types.Direction], strategy types.SpawnStrategy, collision types.SpawnCollision, rng *rand.Rand) ([]*types.Alien, error) {
 	var result []*types.Alien
-	seed := time.Now().UTC().UnixNano()
-	nameGenerator := namegenerator.NewNameGenerator(seed)
-	cityKeys := mapObj.GetCitiesNames()
-	for i := 0; i < numAliens; i++ {
+	nameGenerator := namegenerator.NewNameGenerator(rng.Int63())
+	placements, err := strategy.Place(numAliens, mapObj, rng)
+	if err != nil {
+		return nil, err
+	}
+	placements, err = types.ResolveSpawnCollisions(placements, mapObj, collision, rng)
+	if err != nil {
+		return nil, err
+	}
+	for i, placement := range placements {
 		name := nameGenerator.Generate()
-		cityName := getRandomItem(cityKeys)
-		alien := types.NewAlien(i, name, cityName, mapObj)
+		if placement.Name != "" {
+			name = placement.Name
+		}
+		alien := types.NewAlien(i, name, placement.City, mapObj)
 		result = append(result, &alien)
 	}
-	return result
+	return result, nil
+}
+
+// LoadSpawnStrategy creates the spawn strategy of a spec. The 'file:path' spec reads the placements from path on fs,
+// other specs are parsed by types.ParseSpawnStrategy.
+func LoadSpawnStrategy(spec string, fs FileSystem) (types.SpawnStrategy, error) {
+	if !strings.HasPrefix(spec, "file:") {
+		return types.ParseSpawnStrategy(spec)
+	}
+	path := strings.TrimPrefix(spec, "file:")
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	placements, err := types.ReadSpawnPlacements(file)
+	if err != nil {
+		return nil, err
+	}
+	return types.ExplicitSpawn{Placements: placements}, nil
+}
+
+var newMapFromReader = types.ReadMap
+
+// DefaultMaxIterations is the max number of moves per alien of runs that do not set one.
+const DefaultMaxIterations = 10000
+
+// AlienGroup is a number of aliens moving with the same strategy. The aliens use the simulator default strategy
+// when Strategy is nil.
+type AlienGroup struct {
+	Count    int
+	Strategy types.MovementStrategy
+}
+
+// SimulationConfig holds the settings of a simulation run.
+type SimulationConfig struct {
+	FilePath      string
+	NumAliens     int
+	MaxIterations int
+	Verbose       bool
+	Seed          int64
+	// MapOptions changes how the map file is loaded.
+	MapOptions types.MapOptions
+	// EventsOut is the path where events are written as JSON lines. Use "-" for stdout and "" to disable it.
+	EventsOut string
+	// OutMap is the path where the surviving map is written. Use "" to disable it.
+	OutMap string
+	// OutMapFormat is the map format of OutMap. Uses the format matching the OutMap extension when empty.
+	OutMapFormat string
+	// KeepIsolatedCities writes surviving cities without roads into a text OutMap as lines with only the city name.
+	KeepIsolatedCities bool
+	// Collision decides what happens when aliens meet. Uses the simulator default when nil.
+	Collision types.CollisionPolicy
+	// Strategies are shared among the aliens following their percentages. Aliens use the simulator default when nil.
+	Strategies types.StrategyMix
+	// TickMode decides how the moves of an iteration are resolved. Uses the simulator default when empty.
+	TickMode types.TickMode
+	// Spawn decides the cities the aliens land on. Aliens land on random cities when nil.
+	Spawn types.SpawnStrategy
+	// SpawnCollision decides what happens with the aliens landing on the same city. They coexist when empty.
+	SpawnCollision types.SpawnCollision
+	// Groups give the aliens their strategies in order, the first group to the first aliens. Overrides Strategies.
+	Groups []AlienGroup
+	// Stop ends the simulation early once one of its conditions is met.
+	Stop types.StopConditions
+	// TrackOccupancy counts the aliens on a city from landing until they leave it.
+	TrackOccupancy bool
+}
+
+// mapOptions returns the options to load the map, with the format matching the FilePath extension unless it is set.
+func (config SimulationConfig) mapOptions() types.MapOptions {
+	options := config.MapOptions
+	if options.Format == "" {
+		options.Format = types.MapFormatForPath(config.FilePath)
+	}
+	return options
+}
+
+// nopWriteCloser wraps writers that must not be closed, like os.Stdout.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// createOutput creates the file at path on fs, or returns stdout when path is "-".
+func createOutput(path string, fs FileSystem) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return fs.Create(path)
 }
 
-var newMapFromReader = types.NewMapFromReader
+// StartSimulation starts the alien invasion simulation. By parsing text file and building the types.AlienSimulator object.
+// The same map, number of aliens and seed always produce the same simulation. Returns the report of the invaded world.
+func StartSimulation(config SimulationConfig, fs FileSystem) (types.Report, error) {
+	log.Printf("Starting Invasion with: %d aliens (seed %d)...", config.NumAliens, config.Seed)
+	log.Printf("Building Map from: %s...", config.FilePath)
+	file, err := fs.Open(config.FilePath)
 
-// StartSimulation starts the alien invasion simulation. By parsing text file and building the types.AlienSimulator object
-func StartSimulation(filePath string, numAliens int, fs FileSystem, maxIterations int, verbose bool) error {
-	log.Printf("Starting Invasion with: %d aliens...", numAliens)
-	log.Printf("Building Map from: %s...", filePath)
-	file, err := fs.Open(filePath)
+	if err != nil {
+		return types.Report{}, err
+	}
 
+	mapObj, err := newMapFromReader(file, config.mapOptions())
 	if err != nil {
-		return err
+		return types.Report{}, err
+	}
+	sim, err := newSimulator(mapObj, config)
+	if err != nil {
+		return types.Report{}, err
 	}
 
-	mapObj, err := newMapFromReader(file)
+	var jsonSink *types.JSONLinesSink
+	var events io.WriteCloser
+	if config.EventsOut != "" {
+		events, err = createOutput(config.EventsOut, fs)
+		if err != nil {
+			return types.Report{}, err
+		}
+		jsonSink = types.NewJSONLinesSink(events)
+		sim.Sink = types.MultiSink{sim.Sink, jsonSink}
+	}
+
+	err = sim.SimulateInvasion()
+	if events != nil {
+		closeErr := events.Close()
+		if err == nil {
+			err = jsonSink.Err()
+		}
+		if err == nil {
+			err = closeErr
+		}
+	}
 	if err != nil {
-		return err
+		return sim.Report(), err
+	}
+	if config.OutMap != "" {
+		format := config.OutMapFormat
+		if format == "" {
+			format = types.MapFormatForPath(config.OutMap)
+		}
+		if err := writeMap(mapObj, config.OutMap, format, types.ExportOptions{KeepIsolatedCities: config.KeepIsolatedCities}, fs); err != nil {
+			return sim.Report(), err
+		}
+	}
+	return sim.Report(), nil
+}
+
+// newSimulator spawns the aliens on the map and builds the simulator following the config. A single random source
+// seeded with config.Seed is shared by spawning and the simulation.
+func newSimulator(mapObj *types.Map[types.City, types.Direction], config SimulationConfig) (*types.AlienSimulator, error) {
+	rng := rand.New(rand.NewSource(config.Seed))
+	spawn := config.Spawn
+	if spawn == nil {
+		spawn = types.UniformSpawn{}
+	}
+	aliens, err := placeAliens(config.NumAliens, mapObj, spawn, config.SpawnCollision, rng)
+	if err != nil {
+		return nil, err
+	}
+	if config.Groups != nil {
+		i := 0
+		for _, group := range config.Groups {
+			for j := 0; j < group.Count && i < len(aliens); j++ {
+				aliens[i].Strategy = group.Strategy
+				i++
+			}
+		}
+	} else if config.Strategies != nil {
+		for i, strategy := range config.Strategies.Assign(len(aliens), rng) {
+			aliens[i].Strategy = strategy
+		}
+	}
+	sim := types.NewAlienSimulator(mapObj, aliens, config.MaxIterations, config.Verbose)
+	sim.Seed = config.Seed
+	sim.Rand = rng
+	if config.Collision != nil {
+		sim.Collision = config.Collision
+	}
+	if config.TickMode != "" {
+		sim.TickMode = config.TickMode
 	}
-	aliens := spawnAliens(numAliens, mapObj)
-	sim := types.NewAlienSimulator(mapObj, aliens, maxIterations, verbose)
+	sim.SpawnCollision = config.SpawnCollision
+	sim.Stop = config.Stop
+	sim.TrackOccupancy = config.TrackOccupancy
+	return &sim, nil
+}
 
-	sim.SimulateInvasion()
-	return nil
+// writeMap writes the map on the named format into path.
+func writeMap(mapObj *types.Map[types.City, types.Direction], path string, format string, options types.ExportOptions, fs FileSystem) error {
+	out, err := createOutput(path, fs)
+	if err != nil {
+		return err
+	}
+	if err := types.WriteMap(out, mapObj, format, options); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
 }
@@ -3,6 +3,7 @@ package aliemsim
 import (
 	"alien-invasion-simulator/pkg/aliemsim/types"
 	"github.com/stretchr/testify/suite"
+	"math/rand"
 	"testing"
 )
 
@@ -21,27 +22,44 @@ func (s *SimulationTestSuite) TestSpawnAliens() {
 		"city2": &types.City{Name: "city2"},
 		"city3": &types.City{Name: "city3"},
 	}}
-	aliens := spawnAliens(numAliens, &mapobj)
+	aliens := spawnAliens(numAliens, &mapobj, rand.New(rand.NewSource(1)))
 	s.Equal(len(aliens), numAliens)
 	for _, al := range aliens {
 		s.NotEqual(al.Name, "")
 	}
 }
 
+// TestSpawnAliensSameSeed tests that spawning with the same seed gives the same aliens
+func (s *SimulationTestSuite) TestSpawnAliensSameSeed() {
+	mapobj := types.Map[types.City, types.Direction]{Cities: map[string]*types.City{
+		"city1": &types.City{Name: "city1"},
+		"city2": &types.City{Name: "city2"},
+		"city3": &types.City{Name: "city3"},
+	}}
+	aliens1 := spawnAliens(20, &mapobj, rand.New(rand.NewSource(42)))
+	aliens2 := spawnAliens(20, &mapobj, rand.New(rand.NewSource(42)))
+	for i := range aliens1 {
+		s.Equal(aliens1[i].Name, aliens2[i].Name)
+		s.Equal(aliens1[i].CurrentCityName, aliens2[i].CurrentCityName)
+	}
+}
+
 // TestStartSimulationSuccess tests a successful simulation start
 func (s *SimulationTestSuite) TestStartSimulationSuccess() {
 	mockFs := fakeFS{}
 	newMapFromReader = types.NewMapFromReaderMock
-	err := StartSimulation("/home/cities.txt", 10, mockFs, 10, true)
+	report, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 10, MaxIterations: 10, Verbose: true, Seed: 1}, mockFs)
 	s.Nil(err)
-	newMapFromReader = types.NewMapFromReader
+	s.Equal(10, len(report.Aliens))
+	s.Equal(int64(1), report.Seed)
+	newMapFromReader = types.ReadMap
 
 }
 
 // TestStartSimulationWrongFile tests an incorrect path being sent
 func (s *SimulationTestSuite) TestStartSimulationWrongFile() {
 	mockFs := fakeFSErr{}
-	err := StartSimulation("some wrong path", 100, mockFs, 10, true)
+	_, err := StartSimulation(SimulationConfig{FilePath: "some wrong path", NumAliens: 100, MaxIterations: 10, Verbose: true, Seed: 1}, mockFs)
 	s.NotNil(err)
 	s.EqualError(err, FileOpenErrMock.Error())
 
@@ -51,9 +69,124 @@ func (s *SimulationTestSuite) TestStartSimulationWrongFile() {
 func (s *SimulationTestSuite) TestStartSimulationErrorMap() {
 	mockFs := fakeFS{}
 	newMapFromReader = types.NewMapFromReaderMockErr
-	err := StartSimulation("/home/cities.txt", 100, mockFs, 10, true)
+	_, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 100, MaxIterations: 10, Verbose: true, Seed: 1}, mockFs)
 	s.NotNil(err)
 	s.EqualError(err, types.ErrorNewMapMock.Error())
-	newMapFromReader = types.NewMapFromReader
+	newMapFromReader = types.ReadMap
+
+}
+
+// TestStartSimulationEventsOut tests writing the events file
+func (s *SimulationTestSuite) TestStartSimulationEventsOut() {
+	newMapFromReader = types.NewMapFromReaderMock
+	_, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, EventsOut: "events.jsonl"}, fakeFS{})
+	s.Nil(err)
+	newMapFromReader = types.ReadMap
+}
+
+// TestStartSimulationEventsOutErr tests an error creating the events file
+func (s *SimulationTestSuite) TestStartSimulationEventsOutErr() {
+	newMapFromReader = types.NewMapFromReaderMock
+	_, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, EventsOut: "events.jsonl"}, fakeFSCreateErr{})
+	s.NotNil(err)
+	s.EqualError(err, FileCreateErrMock.Error())
+	newMapFromReader = types.ReadMap
+}
+
+// TestStartSimulationEventsOutCloseErr tests an error closing the events file
+func (s *SimulationTestSuite) TestStartSimulationEventsOutCloseErr() {
+	newMapFromReader = types.NewMapFromReaderMock
+	_, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, EventsOut: "events.jsonl"}, fakeFSCloseErr{fakeFS{}})
+	s.ErrorIs(err, FileCloseErrMock)
+	newMapFromReader = types.ReadMap
+}
+
+// TestStartSimulationOutMap tests writing the surviving map
+func (s *SimulationTestSuite) TestStartSimulationOutMap() {
+	newMapFromReader = types.NewMapFromReaderMock
+	_, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, OutMap: "out.txt", KeepIsolatedCities: true}, fakeFS{})
+	s.Nil(err)
+	_, err = StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, OutMap: "out.txt"}, fakeFSCreateErr{})
+	s.NotNil(err)
+	s.EqualError(err, FileCreateErrMock.Error())
+	newMapFromReader = types.ReadMap
+}
+
+// TestStartSimulationStrategies tests a simulation where every alien stays put
+func (s *SimulationTestSuite) TestStartSimulationStrategies() {
+	newMapFromReader = types.NewMapFromReaderMock
+	mix, _ := types.ParseStrategyMix("stay-put")
+	report, err := StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 3, MaxIterations: 10, Seed: 1, Strategies: mix}, fakeFS{})
+	s.Nil(err)
+	s.Equal(types.StopAllAliensDone, report.StopReason)
+	for _, alien := range report.Aliens {
+		s.Equal(0, alien.NumMovements)
+	}
+	newMapFromReader = types.ReadMap
+}
+
+// TestStartSimulationMapFormats tests that the map format follows the file extension unless it is set
+func (s *SimulationTestSuite) TestStartSimulationMapFormats() {
+	fromText, err := StartSimulation(SimulationConfig{FilePath: sampleMap, NumAliens: 4, MaxIterations: 10, Seed: 2}, OsFS)
+	s.Nil(err)
+	fromJSON, err := StartSimulation(SimulationConfig{FilePath: "../../sampleMapFiles/cities1.json", NumAliens: 4, MaxIterations: 10, Seed: 2}, OsFS)
+	s.Nil(err)
+	s.Equal(fromText, fromJSON)
+	_, err = StartSimulation(SimulationConfig{FilePath: sampleMap, MapOptions: types.MapOptions{Format: "json"}}, OsFS)
+	s.ErrorIs(err, types.ErrorInvalidMapDocument)
+	newMapFromReader = types.NewMapFromReaderMock
+	_, err = StartSimulation(SimulationConfig{FilePath: "/home/cities.txt", NumAliens: 2, MaxIterations: 10, Seed: 1, OutMap: "out.map", OutMapFormat: "xml"}, fakeFS{})
+	s.ErrorIs(err, types.ErrorUnknownMapFormat)
+	newMapFromReader = types.ReadMap
+}
+
+// TestLoadSpawnStrategy tests loading built-in spawn strategies and placements files
+func (s *SimulationTestSuite) TestLoadSpawnStrategy() {
+	strategy, err := LoadSpawnStrategy("degree", OsFS)
+	s.Nil(err)
+	s.Equal(types.DegreeSpawn{}, strategy)
+	_, err = LoadSpawnStrategy("everywhere", OsFS)
+	s.ErrorIs(err, types.ErrorInvalidSpawn)
+	_, err = LoadSpawnStrategy("file:landing.txt", fakeFSErr{})
+	s.EqualError(err, FileOpenErrMock.Error())
+
+	strategy, err = LoadSpawnStrategy("file:../../sampleMapFiles/landing.txt", OsFS)
+	s.Nil(err)
+	report, err := StartSimulation(SimulationConfig{FilePath: sampleMap, NumAliens: 2, MaxIterations: 10, Seed: 1, Spawn: strategy}, OsFS)
+	s.Nil(err)
+	s.Equal("zorg", report.Aliens[0].Name)
+	s.Equal("blip", report.Aliens[1].Name)
+	_, err = StartSimulation(SimulationConfig{FilePath: sampleMap, NumAliens: 4, MaxIterations: 10, Seed: 1, Spawn: strategy}, OsFS)
+	s.ErrorIs(err, types.ErrorInvalidSpawn)
+}
+
+// TestStartSimulationSpawnCollision tests that spawn strategies and collision policies reach the simulation
+func (s *SimulationTestSuite) TestStartSimulationSpawnCollision() {
+	mix, _ := types.ParseStrategyMix("stay-put")
+	config := SimulationConfig{FilePath: sampleMap, NumAliens: 5, MaxIterations: 10, Seed: 1, Strategies: mix, Spawn: types.OnePerCitySpawn{}}
+	report, err := StartSimulation(config, OsFS)
+	s.Nil(err)
+	s.Empty(report.DestroyedCities)
+
+	config.Spawn = types.ClusteredSpawn{Sites: []string{"Bee"}, Radius: 0}
+	config.SpawnCollision = types.SpawnReject
+	_, err = StartSimulation(config, OsFS)
+	s.ErrorIs(err, types.ErrorInvalidSpawn)
+
+	config.SpawnCollision = types.SpawnFight
+	report, err = StartSimulation(config, OsFS)
+	s.Nil(err)
+	s.Len(report.DestroyedCities, 1)
+	s.Equal("Bee", report.DestroyedCities[0].Name)
+
+	config.SpawnCollision = types.SpawnRelocate
+	report, err = StartSimulation(config, OsFS)
+	s.Nil(err)
+	s.Empty(report.DestroyedCities)
 
+	config.NumAliens = 6
+	config.Spawn = types.OnePerCitySpawn{}
+	config.SpawnCollision = types.SpawnCoexist
+	_, err = StartSimulation(config, OsFS)
+	s.ErrorIs(err, types.ErrorInvalidSpawn)
 }
@@ -1,9 +0,0 @@
-package aliemsim
-
-import (
-	"math/rand"
-)
-
-func getRandomItem(arr []string) string {
-	return arr[rand.Intn(len(arr))]
-}
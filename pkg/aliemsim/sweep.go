@@ -0,0 +1,74 @@
+package aliemsim
+
+import (
+	"alien-invasion-simulator/pkg/aliemsim/types"
+	"errors"
+	"log"
+)
+
+var ErrorEmptySweep = errors.New("Sweep needs at least one number of aliens.")
+
+// SweepConfig holds the parameters varied by a sweep. Every combination runs a batch with the same base seed.
+type SweepConfig struct {
+	BatchConfig
+	// Aliens are the numbers of aliens to try.
+	Aliens []int
+	// MaxIterations are the max moves per alien to try. Uses the config MaxIterations when empty.
+	MaxIterations []int
+	// Thresholds are the collision thresholds to try. Uses the config Collision when empty.
+	Thresholds []int
+}
+
+// RunSweep reads the map once and runs a batch for every combination of aliens, max iterations and threshold.
+// Returns a row of outcome metrics per combination.
+func RunSweep(config SweepConfig, fs FileSystem) (types.SweepReport, error) {
+	if len(config.Aliens) == 0 {
+		return nil, ErrorEmptySweep
+	}
+	if config.Runs <= 0 {
+		return nil, ErrorInvalidRuns
+	}
+	maxIterations := config.MaxIterations
+	if len(maxIterations) == 0 {
+		maxIterations = []int{config.SimulationConfig.MaxIterations}
+	}
+	thresholds := config.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = []int{0}
+	}
+	file, err := fs.Open(config.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	mapObj, err := newMapFromReader(file, config.mapOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	report := types.SweepReport{}
+	for _, numAliens := range config.Aliens {
+		for _, maxIter := range maxIterations {
+			for _, threshold := range thresholds {
+				batchConfig := config.BatchConfig
+				batchConfig.NumAliens = numAliens
+				batchConfig.SimulationConfig.MaxIterations = maxIter
+				if threshold > 0 {
+					batchConfig.Collision = types.ThresholdCollision{Threshold: threshold}
+				}
+				log.Printf("Sweeping %d aliens - max iterations %d - threshold %d...", numAliens, maxIter, threshold)
+				batch, err := runBatch(mapObj, batchConfig)
+				if err != nil {
+					return nil, err
+				}
+				report = append(report, types.SweepRow{
+					NumAliens:     numAliens,
+					MaxIterations: maxIter,
+					Threshold:     threshold,
+					BatchReport:   batch,
+				})
+			}
+		}
+	}
+	return report, nil
+}
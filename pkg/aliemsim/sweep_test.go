@@ -0,0 +1,54 @@
+package aliemsim
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SweepTestSuite struct {
+	suite.Suite
+}
+
+func TestSweepSuite(t *testing.T) {
+	suite.Run(t, &SweepTestSuite{})
+}
+
+func (s *SweepTestSuite) TestRunSweep() {
+	config := SweepConfig{
+		BatchConfig: BatchConfig{
+			SimulationConfig: SimulationConfig{FilePath: sampleMap, MaxIterations: 50, Seed: 3},
+			Runs:             10,
+		},
+		Aliens:     []int{1, 6},
+		Thresholds: []int{2, 7},
+	}
+	report, err := RunSweep(config, OsFS)
+	s.Nil(err)
+	s.Equal(4, len(report))
+	for _, row := range report {
+		s.Equal(50, row.MaxIterations)
+		s.Equal(10, row.Runs)
+	}
+	// one alien never meets another one, and 7 aliens are needed to destroy a city with threshold 7
+	s.Equal(1.0, report[0].CitySurvivalRate)
+	s.Equal(1.0, report[3].CitySurvivalRate)
+	s.Equal(6, report[2].NumAliens)
+	s.Equal(2, report[2].Threshold)
+
+	single, err := RunBatch(BatchConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap, NumAliens: 6, MaxIterations: 50, Seed: 3}, Runs: 10}, OsFS)
+	s.Nil(err)
+	s.Equal(single, report[2].BatchReport)
+}
+
+func (s *SweepTestSuite) TestRunSweepErrors() {
+	config := SweepConfig{BatchConfig: BatchConfig{SimulationConfig: SimulationConfig{FilePath: sampleMap}, Runs: 1}}
+	_, err := RunSweep(config, OsFS)
+	s.ErrorIs(err, ErrorEmptySweep)
+	config.Aliens = []int{1}
+	config.Runs = 0
+	_, err = RunSweep(config, OsFS)
+	s.ErrorIs(err, ErrorInvalidRuns)
+	config.Runs = 1
+	_, err = RunSweep(config, fakeFSErr{})
+	s.ErrorIs(err, FileOpenErrMock)
+}
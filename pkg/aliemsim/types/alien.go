@@ -11,6 +11,27 @@ type Alien struct {
 	IsDead          bool
 	NumMovements    int
 	CanMove         bool
+	// Strategy decides how the alien moves, the simulator default is used when nil.
+	Strategy MovementStrategy
+	// Transit is the road the alien is travelling on, nil while it is on a city. CurrentCityName keeps the city it
+	// left until it arrives.
+	Transit *Transit
+}
+
+// Transit is a trip along a road that takes more than one iteration.
+type Transit struct {
+	From      string    `json:"from" yaml:"from"`
+	To        string    `json:"to" yaml:"to"`
+	Direction Direction `json:"direction" yaml:"direction"`
+	// Departure is the iteration the alien left its city.
+	Departure int `json:"departure" yaml:"departure"`
+	// Arrival is the iteration the alien reaches the end of the road.
+	Arrival int `json:"arrival" yaml:"arrival"`
+}
+
+// ToString returns the trip as 'direction from A to B until iteration N'.
+func (t *Transit) ToString() string {
+	return fmt.Sprintf("%s from %s to %s until iteration %d", t.Direction, t.From, t.To, t.Arrival)
 }
 
 func (a *Alien) ToString() string {
@@ -21,6 +42,9 @@ func (a *Alien) ToString() string {
 		a.IsDead,
 		a.NumMovements,
 		a.CanMove)
+	if a.Transit != nil {
+		s += " - On Road: " + a.Transit.ToString()
+	}
 	return s
 }
 
@@ -52,11 +76,12 @@ func (a *Alien) isTrapped() (bool, error) {
 }
 
 // invadeCity changes the current city of an alien, adds the alien to the city obj, and increments the number of movements counter.
+// Aliens coming back to a city that still counts them are not added twice.
 func (a *Alien) invadeCity(c *City) *City {
 	if !c.hasAlien(a) {
 		c.Aliens = append(c.Aliens, a)
-		a.CurrentCityName = c.Name
-		a.NumMovements += 1
 	}
+	a.CurrentCityName = c.Name
+	a.NumMovements += 1
 	return c
 }
@@ -93,3 +93,16 @@ func (s *AlienTestSuite) TestInvadeCity() {
 	s.Equal(alien.CurrentCityName, cityAfterInvasion.Name)
 
 }
+
+// TestInvadeCityAgain tests that an alien coming back to a city that still counts it moves without being added twice
+func (s *AlienTestSuite) TestInvadeCityAgain() {
+	a := &City{Name: "a"}
+	b := &City{Name: "b"}
+	alien := NewAlien(1, "An alien", "a", &Map[City, Direction]{})
+	alien.invadeCity(b)
+	alien.invadeCity(a)
+	alien.invadeCity(b)
+	s.Equal(3, alien.NumMovements)
+	s.Equal("b", alien.CurrentCityName)
+	s.Len(b.Aliens, 1)
+}
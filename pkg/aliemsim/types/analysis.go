@@ -0,0 +1,126 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MapAnalysis describes how aliens can move around a map, to characterize it before simulating.
+type MapAnalysis struct {
+	Cities int `json:"cities" yaml:"cities"`
+	Roads  int `json:"roads" yaml:"roads"`
+	// Components are the groups of cities linked by roads on any direction.
+	Components [][]string `json:"components" yaml:"components"`
+	// Regions are the groups of cities where every city can reach every other one.
+	Regions [][]string `json:"regions" yaml:"regions"`
+	// TrapRegions are the regions of more than one city that aliens can enter from another region but never leave.
+	TrapRegions [][]string `json:"trap_regions" yaml:"trap_regions"`
+	// DeadEnds are the cities without roads leaving them, where aliens are trapped.
+	DeadEnds []string `json:"dead_ends" yaml:"dead_ends"`
+	// Chokepoints are the cities that split their component when destroyed.
+	Chokepoints []string `json:"chokepoints" yaml:"chokepoints"`
+	// Bridges are the links between 2 cities that split their component when their roads collapse.
+	Bridges []AnalysisLink `json:"bridges" yaml:"bridges"`
+}
+
+// AnalysisLink are the roads between 2 cities on any direction. From is sorted before To.
+type AnalysisLink struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// AnalyzeMap finds the components, trap regions, dead ends and chokepoints of the map.
+func AnalyzeMap(m *Map[City, Direction]) MapAnalysis {
+	analysis := MapAnalysis{
+		Cities:      len(m.Cities),
+		Roads:       len(m.Graph.GetEdges()),
+		Components:  componentNames(m.Graph.WeaklyConnectedComponents()),
+		TrapRegions: [][]string{},
+		DeadEnds:    []string{},
+		Chokepoints: idNames(m.Graph.ArticulationPoints()),
+		Bridges:     []AnalysisLink{},
+	}
+	regions := m.Graph.StronglyConnectedComponents()
+	analysis.Regions = componentNames(regions)
+	regionOf := map[graph.VertexID]int{}
+	for i, region := range regions {
+		for _, id := range region {
+			regionOf[id] = i
+		}
+	}
+	for i, region := range regions {
+		leaves, entered := false, false
+		for _, id := range region {
+			for to := range m.Graph.Neighbors(id) {
+				leaves = leaves || regionOf[to] != i
+			}
+			for from := range m.Graph.Incoming(id) {
+				entered = entered || regionOf[from] != i
+			}
+		}
+		if len(region) > 1 && entered && !leaves {
+			analysis.TrapRegions = append(analysis.TrapRegions, analysis.Regions[i])
+		}
+	}
+	for _, name := range m.GetCitiesNames() {
+		if len(m.Graph.Neighbors(graph.VertexID(name))) == 0 {
+			analysis.DeadEnds = append(analysis.DeadEnds, name)
+		}
+	}
+	for _, bridge := range m.Graph.Bridges() {
+		analysis.Bridges = append(analysis.Bridges, AnalysisLink{From: string(bridge.From), To: string(bridge.To)})
+	}
+	return analysis
+}
+
+// componentNames returns the components as city names.
+func componentNames(components [][]graph.VertexID) [][]string {
+	result := make([][]string, 0, len(components))
+	for _, component := range components {
+		result = append(result, idNames(component))
+	}
+	return result
+}
+
+// idNames returns the vertex ids as city names.
+func idNames(ids []graph.VertexID) []string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, string(id))
+	}
+	return names
+}
+
+// Write writes the analysis into w on the given format. Format must be one of ReportFormats.
+func (a MapAnalysis) Write(w io.Writer, format string) error {
+	return writeFormatted(w, format, a, a.ToString)
+}
+
+// ToString returns the human readable representation of the analysis.
+func (a MapAnalysis) ToString() string {
+	result := fmt.Sprintf("Cities: %d\nRoads: %d\n", a.Cities, a.Roads)
+	groups := func(title string, items [][]string) {
+		result += fmt.Sprintf("%s: %d\n", title, len(items))
+		for _, item := range items {
+			result += fmt.Sprintf("  %s\n", strings.Join(item, " "))
+		}
+	}
+	groups("Components", a.Components)
+	groups("Regions", a.Regions)
+	groups("Trap regions", a.TrapRegions)
+	result += fmt.Sprintf("Dead ends: %d\n", len(a.DeadEnds))
+	if len(a.DeadEnds) > 0 {
+		result += fmt.Sprintf("  %s\n", strings.Join(a.DeadEnds, " "))
+	}
+	result += fmt.Sprintf("Chokepoints: %d\n", len(a.Chokepoints))
+	if len(a.Chokepoints) > 0 {
+		result += fmt.Sprintf("  %s\n", strings.Join(a.Chokepoints, " "))
+	}
+	result += fmt.Sprintf("Bridges: %d\n", len(a.Bridges))
+	for _, bridge := range a.Bridges {
+		result += fmt.Sprintf("  %s - %s\n", bridge.From, bridge.To)
+	}
+	return result
+}
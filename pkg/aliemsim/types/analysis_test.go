@@ -0,0 +1,80 @@
+package types
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type AnalysisTestSuite struct {
+	suite.Suite
+}
+
+func TestAnalysisTestSuite(t *testing.T) {
+	suite.Run(t, &AnalysisTestSuite{})
+}
+
+// analysisMap has the loop Foo <-> Bar, a one way road from Bar into the loop Baz <-> Qux, the dead end Bee and
+// the isolated Zed.
+const analysisMap = "Foo north=Bar\nBar south=Foo east=Baz west=Bee\nBaz east=Qux\nQux west=Baz\nBee\nZed\n"
+
+func (s *AnalysisTestSuite) TestAnalyzeMap() {
+	m, err := NewMapFromReader(strings.NewReader(analysisMap))
+	s.Nil(err)
+	analysis := AnalyzeMap(m)
+	s.Equal(MapAnalysis{
+		Cities:      6,
+		Roads:       6,
+		Components:  [][]string{{"Bar", "Baz", "Bee", "Foo", "Qux"}, {"Zed"}},
+		Regions:     [][]string{{"Bar", "Foo"}, {"Baz", "Qux"}, {"Bee"}, {"Zed"}},
+		TrapRegions: [][]string{{"Baz", "Qux"}},
+		DeadEnds:    []string{"Bee", "Zed"},
+		Chokepoints: []string{"Bar", "Baz"},
+		Bridges:     []AnalysisLink{{From: "Bar", To: "Baz"}, {From: "Bar", To: "Bee"}, {From: "Bar", To: "Foo"}, {From: "Baz", To: "Qux"}},
+	}, analysis)
+
+	// a symmetric loop has no traps nor chokepoints
+	m, _ = NewMapFromReader(strings.NewReader("a east=b\nb west=a east=c\nc west=b east=a\n"))
+	s.Nil(m.AddPath("a", "c", "west"))
+	analysis = AnalyzeMap(m)
+	s.Equal([][]string{{"a", "b", "c"}}, analysis.Regions)
+	s.Empty(analysis.TrapRegions)
+	s.Empty(analysis.DeadEnds)
+	s.Empty(analysis.Chokepoints)
+	s.Empty(analysis.Bridges)
+}
+
+func (s *AnalysisTestSuite) TestWriteAnalysis() {
+	m, _ := NewMapFromReader(strings.NewReader(analysisMap))
+	analysis := AnalyzeMap(m)
+	s.Equal(`Cities: 6
+Roads: 6
+Components: 2
+  Bar Baz Bee Foo Qux
+  Zed
+Regions: 4
+  Bar Foo
+  Baz Qux
+  Bee
+  Zed
+Trap regions: 1
+  Baz Qux
+Dead ends: 2
+  Bee Zed
+Chokepoints: 2
+  Bar Baz
+Bridges: 4
+  Bar - Baz
+  Bar - Bee
+  Bar - Foo
+  Baz - Qux
+`, analysis.ToString())
+
+	for _, format := range []string{"json", "yaml"} {
+		buf := &bytes.Buffer{}
+		s.Nil(analysis.Write(buf, format))
+		s.Contains(buf.String(), "trap_regions")
+	}
+	s.ErrorIs(analysis.Write(&bytes.Buffer{}, "csv"), ErrorInvalidReportFormat)
+}
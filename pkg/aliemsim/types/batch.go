@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// BatchReport aggregates the reports of many simulations over the same map.
+type BatchReport struct {
+	Runs              int                `json:"runs" yaml:"runs"`
+	Seed              int64              `json:"seed" yaml:"seed"`
+	Cities            []CityDestruction  `json:"cities" yaml:"cities"`
+	Iterations        IterationStats     `json:"iterations" yaml:"iterations"`
+	AlienSurvivalRate float64            `json:"alien_survival_rate" yaml:"alien_survival_rate"`
+	CitySurvivalRate  float64            `json:"city_survival_rate" yaml:"city_survival_rate"`
+	StopReasons       map[StopReason]int `json:"stop_reasons" yaml:"stop_reasons"`
+}
+
+// CityDestruction is the fraction of runs where a city was destroyed.
+type CityDestruction struct {
+	Name        string  `json:"name" yaml:"name"`
+	Probability float64 `json:"probability" yaml:"probability"`
+}
+
+// IterationStats describes the number of iterations the runs needed to finish.
+type IterationStats struct {
+	Mean float64 `json:"mean" yaml:"mean"`
+	Min  int     `json:"min" yaml:"min"`
+	Max  int     `json:"max" yaml:"max"`
+	P50  int     `json:"p50" yaml:"p50"`
+	P90  int     `json:"p90" yaml:"p90"`
+	P99  int     `json:"p99" yaml:"p99"`
+}
+
+// NewBatchReport aggregates the reports of runs started from the base seed.
+func NewBatchReport(seed int64, reports []Report) BatchReport {
+	batch := BatchReport{
+		Runs:        len(reports),
+		Seed:        seed,
+		Cities:      []CityDestruction{},
+		StopReasons: map[StopReason]int{},
+	}
+	if len(reports) == 0 {
+		return batch
+	}
+	destroyed := map[string]int{}
+	iterations := make([]int, 0, len(reports))
+	totalAliens, aliveAliens, totalCities, aliveCities := 0, 0, 0, 0
+	sum := 0
+	for _, report := range reports {
+		for _, city := range report.SurvivingCities {
+			if _, ok := destroyed[city.Name]; !ok {
+				destroyed[city.Name] = 0
+			}
+		}
+		for _, city := range report.DestroyedCities {
+			destroyed[city.Name] += 1
+		}
+		totalCities += len(report.SurvivingCities) + len(report.DestroyedCities)
+		aliveCities += len(report.SurvivingCities)
+		for _, alien := range report.Aliens {
+			totalAliens += 1
+			if !alien.Dead {
+				aliveAliens += 1
+			}
+		}
+		iterations = append(iterations, report.Iterations)
+		sum += report.Iterations
+		batch.StopReasons[report.StopReason] += 1
+	}
+
+	for name, count := range destroyed {
+		batch.Cities = append(batch.Cities, CityDestruction{Name: name, Probability: float64(count) / float64(len(reports))})
+	}
+	sort.Slice(batch.Cities, func(i, j int) bool {
+		if batch.Cities[i].Probability != batch.Cities[j].Probability {
+			return batch.Cities[i].Probability > batch.Cities[j].Probability
+		}
+		return batch.Cities[i].Name < batch.Cities[j].Name
+	})
+
+	sort.Ints(iterations)
+	batch.Iterations = IterationStats{
+		Mean: float64(sum) / float64(len(iterations)),
+		Min:  iterations[0],
+		Max:  iterations[len(iterations)-1],
+		P50:  percentile(iterations, 50),
+		P90:  percentile(iterations, 90),
+		P99:  percentile(iterations, 99),
+	}
+	if totalAliens > 0 {
+		batch.AlienSurvivalRate = float64(aliveAliens) / float64(totalAliens)
+	}
+	if totalCities > 0 {
+		batch.CitySurvivalRate = float64(aliveCities) / float64(totalCities)
+	}
+	return batch
+}
+
+// percentile returns the nearest rank percentile p of the sorted values.
+func percentile(sorted []int, p float64) int {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Write writes the batch report into w on the given format. Format must be one of ReportFormats.
+func (b BatchReport) Write(w io.Writer, format string) error {
+	return writeFormatted(w, format, b, b.ToString)
+}
+
+// ToString returns the human readable representation of the batch report.
+func (b BatchReport) ToString() string {
+	result := fmt.Sprintf("Runs: %d\nSeed: %d\n", b.Runs, b.Seed)
+	result += fmt.Sprintf("Iterations: mean %.2f - min %d - p50 %d - p90 %d - p99 %d - max %d\n",
+		b.Iterations.Mean, b.Iterations.Min, b.Iterations.P50, b.Iterations.P90, b.Iterations.P99, b.Iterations.Max)
+	result += fmt.Sprintf("Alien survival rate: %.4f\nCity survival rate: %.4f\n", b.AlienSurvivalRate, b.CitySurvivalRate)
+	result += "Stop reasons:\n"
+	reasons := make([]string, 0, len(b.StopReasons))
+	for reason := range b.StopReasons {
+		reasons = append(reasons, string(reason))
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		result += fmt.Sprintf("  %s: %d\n", reason, b.StopReasons[StopReason(reason)])
+	}
+	result += "City destruction probability:\n"
+	for _, city := range b.Cities {
+		result += fmt.Sprintf("  %s: %.4f\n", city.Name, city.Probability)
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatchTestSuite(t *testing.T) {
+	suite.Run(t, &BatchTestSuite{})
+}
+
+func (s *BatchTestSuite) TestNewBatchReport() {
+	reports := []Report{
+		{
+			StopReason:      StopAllAliensDead,
+			Iterations:      10,
+			SurvivingCities: []CityReport{{Name: "a"}},
+			DestroyedCities: []DestroyedCity{{Name: "b"}},
+			Aliens:          []AlienReport{{Dead: true}, {Dead: true}},
+		},
+		{
+			StopReason:      StopAllAliensMaxMoves,
+			Iterations:      30,
+			SurvivingCities: []CityReport{{Name: "a"}, {Name: "b"}},
+			DestroyedCities: []DestroyedCity{},
+			Aliens:          []AlienReport{{Dead: false}, {Dead: false}},
+		},
+		{
+			StopReason:      StopAllAliensDead,
+			Iterations:      20,
+			SurvivingCities: []CityReport{},
+			DestroyedCities: []DestroyedCity{{Name: "a"}, {Name: "b"}},
+			Aliens:          []AlienReport{{Dead: true}, {Dead: true}},
+		},
+	}
+	batch := NewBatchReport(7, reports)
+	s.Equal(3, batch.Runs)
+	s.Equal(int64(7), batch.Seed)
+	s.Equal([]CityDestruction{{Name: "b", Probability: 2.0 / 3}, {Name: "a", Probability: 1.0 / 3}}, batch.Cities)
+	s.Equal(IterationStats{Mean: 20, Min: 10, Max: 30, P50: 20, P90: 30, P99: 30}, batch.Iterations)
+	s.InDelta(1.0/3, batch.AlienSurvivalRate, 1e-9)
+	s.InDelta(0.5, batch.CitySurvivalRate, 1e-9)
+	s.Equal(map[StopReason]int{StopAllAliensDead: 2, StopAllAliensMaxMoves: 1}, batch.StopReasons)
+}
+
+func (s *BatchTestSuite) TestNewBatchReportEmpty() {
+	batch := NewBatchReport(1, []Report{})
+	s.Equal(0, batch.Runs)
+	s.Equal(0, len(batch.Cities))
+}
+
+func (s *BatchTestSuite) TestPercentile() {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	s.Equal(5, percentile(values, 50))
+	s.Equal(9, percentile(values, 90))
+	s.Equal(10, percentile(values, 99))
+	s.Equal(1, percentile(values, 0))
+}
+
+func (s *BatchTestSuite) TestWrite() {
+	batch := NewBatchReport(1, []Report{{StopReason: StopNoCities, SurvivingCities: []CityReport{}}})
+	for _, format := range ReportFormats {
+		var buf bytes.Buffer
+		s.Nil(batch.Write(&buf, format))
+		s.Contains(buf.String(), "no_cities")
+	}
+	s.ErrorIs(batch.Write(&bytes.Buffer{}, "xml"), ErrorInvalidReportFormat)
+}
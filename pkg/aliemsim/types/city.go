@@ -2,8 +2,10 @@ package types
 
 // City structure that contains the city name and all the possible paths to other cities. Might also have aliens!
 type City struct {
-	Name        string
-	Aliens      []*Alien
+	Name   string
+	Aliens []*Alien
+	// Attributes is the metadata of the city loaded from structured map formats. Can be nil.
+	Attributes  map[string]any
 	isDestroyed bool
 }
 
@@ -17,6 +19,27 @@ func (c *City) hasAlien(a2 *Alien) bool {
 	return false
 }
 
+// removeAlien removes the given alien from the city.
+func (c *City) removeAlien(a2 *Alien) {
+	for i, a := range c.Aliens {
+		if a.ID == a2.ID {
+			c.Aliens = append(c.Aliens[:i], c.Aliens[i+1:]...)
+			return
+		}
+	}
+}
+
+// removeDeadAliens removes the dead aliens from the city.
+func (c *City) removeDeadAliens() {
+	alive := c.Aliens[:0]
+	for _, a := range c.Aliens {
+		if !a.IsDead {
+			alive = append(alive, a)
+		}
+	}
+	c.Aliens = alive
+}
+
 // Destroy kills all aliens on the city and marks the city as destroyed
 func (c *City) Destroy() {
 	for _, alien := range c.Aliens {
@@ -26,6 +49,36 @@ func (c *City) Destroy() {
 	c.Aliens = []*Alien{}
 }
 
+// clone returns a copy of the city on the map m. Aliens are copied once, aliens keeps the copy of every alien.
+func (c City) clone(m *Map[City, Direction], aliens map[*Alien]*Alien) City {
+	if c.Attributes != nil {
+		attributes := make(map[string]any, len(c.Attributes))
+		for key, value := range c.Attributes {
+			attributes[key] = value
+		}
+		c.Attributes = attributes
+	}
+	if c.Aliens != nil {
+		cityAliens := make([]*Alien, 0, len(c.Aliens))
+		for _, alien := range c.Aliens {
+			alienClone, ok := aliens[alien]
+			if !ok {
+				copied := *alien
+				copied.Map = m
+				if alien.Transit != nil {
+					transit := *alien.Transit
+					copied.Transit = &transit
+				}
+				alienClone = &copied
+				aliens[alien] = alienClone
+			}
+			cityAliens = append(cityAliens, alienClone)
+		}
+		c.Aliens = cityAliens
+	}
+	return c
+}
+
 // NewCityFromName creates a new city struct from a string name
 func NewCityFromName(name string) City {
 	return City{
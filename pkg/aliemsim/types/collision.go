@@ -0,0 +1,97 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidCollisionPolicy = errors.New("Invalid collision policy.")
+
+// StopLastAlienStanding is the stop reason of LastAlienStandingCollision.
+const StopLastAlienStanding StopReason = "last_alien_standing"
+
+// Collision is the outcome of aliens meeting on a city. When DestroyCity is set every alien on the city dies.
+type Collision struct {
+	DestroyCity bool
+	Dead        []*Alien
+}
+
+// CollisionPolicy decides what happens on a city every time an alien arrives to it.
+type CollisionPolicy interface {
+	Resolve(city *City, rng *rand.Rand) Collision
+}
+
+// collisionStopper is implemented by collision policies that can end the simulation on their own.
+type collisionStopper interface {
+	stopReason(sim *AlienSimulator) (StopReason, bool)
+}
+
+// ThresholdCollision destroys the city and kills all of its aliens once Threshold aliens are on it.
+type ThresholdCollision struct {
+	Threshold int
+}
+
+// Resolve destroys the city when it has at least Threshold aliens.
+func (tc ThresholdCollision) Resolve(city *City, rng *rand.Rand) Collision {
+	if len(city.Aliens) < tc.Threshold {
+		return Collision{}
+	}
+	return Collision{DestroyCity: true, Dead: append([]*Alien{}, city.Aliens...)}
+}
+
+// FightCollision makes the aliens on a city fight. A random alien survives and the city stands.
+type FightCollision struct{}
+
+// Resolve picks a random survivor when more than one alien is on the city.
+func (fc FightCollision) Resolve(city *City, rng *rand.Rand) Collision {
+	if len(city.Aliens) < 2 {
+		return Collision{}
+	}
+	winner := rng.Intn(len(city.Aliens))
+	collision := Collision{}
+	for i, alien := range city.Aliens {
+		if i != winner {
+			collision.Dead = append(collision.Dead, alien)
+		}
+	}
+	return collision
+}
+
+// LastAlienStandingCollision fights like FightCollision and ends the simulation when a single alien is alive.
+type LastAlienStandingCollision struct {
+	FightCollision
+}
+
+func (lc LastAlienStandingCollision) stopReason(sim *AlienSimulator) (StopReason, bool) {
+	return StopLastAlienStanding, len(sim.Aliens)-sim.NumDeadAliens == 1
+}
+
+// ParseCollisionPolicy creates a collision policy from its name: 'threshold:N', 'fight' or 'last-standing'.
+// 'threshold' alone uses the default of 2 aliens.
+func ParseCollisionPolicy(spec string) (CollisionPolicy, error) {
+	name, arg, hasArg := strings.Cut(spec, ":")
+	switch name {
+	case "threshold":
+		threshold := 2
+		if hasArg {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w Threshold needs to be a positive number, got '%s'", ErrorInvalidCollisionPolicy, arg)
+			}
+			threshold = n
+		}
+		return ThresholdCollision{Threshold: threshold}, nil
+	case "fight":
+		if !hasArg {
+			return FightCollision{}, nil
+		}
+	case "last-standing":
+		if !hasArg {
+			return LastAlienStandingCollision{}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w '%s' needs to be one from [threshold:N fight last-standing]", ErrorInvalidCollisionPolicy, spec)
+}
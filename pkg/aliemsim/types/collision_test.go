@@ -0,0 +1,179 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"testing"
+)
+
+type CollisionTestSuite struct {
+	suite.Suite
+}
+
+func TestCollisionTestSuite(t *testing.T) {
+	suite.Run(t, &CollisionTestSuite{})
+}
+
+func cityWithAliens(numAliens int) *City {
+	city := &City{Name: "city"}
+	for i := 0; i < numAliens; i++ {
+		city.Aliens = append(city.Aliens, &Alien{ID: i, Name: "alien"})
+	}
+	return city
+}
+
+func (s *CollisionTestSuite) TestResolve() {
+	vals := []struct {
+		name      string
+		policy    CollisionPolicy
+		numAliens int
+		destroy   bool
+		numDead   int
+	}{
+		{
+			name:      "threshold with less aliens",
+			policy:    ThresholdCollision{Threshold: 3},
+			numAliens: 2,
+			destroy:   false,
+			numDead:   0,
+		},
+		{
+			name:      "threshold reached",
+			policy:    ThresholdCollision{Threshold: 3},
+			numAliens: 3,
+			destroy:   true,
+			numDead:   3,
+		},
+		{
+			name:      "fight with a single alien",
+			policy:    FightCollision{},
+			numAliens: 1,
+			destroy:   false,
+			numDead:   0,
+		},
+		{
+			name:      "fight with 3 aliens",
+			policy:    FightCollision{},
+			numAliens: 3,
+			destroy:   false,
+			numDead:   2,
+		},
+		{
+			name:      "last standing with 2 aliens",
+			policy:    LastAlienStandingCollision{},
+			numAliens: 2,
+			destroy:   false,
+			numDead:   1,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			collision := val.policy.Resolve(cityWithAliens(val.numAliens), rand.New(rand.NewSource(1)))
+			s.Equal(val.destroy, collision.DestroyCity)
+			s.Equal(val.numDead, len(collision.Dead))
+		})
+	}
+}
+
+func (s *CollisionTestSuite) TestParseCollisionPolicy() {
+	vals := []struct {
+		spec    string
+		policy  CollisionPolicy
+		withErr bool
+	}{
+		{spec: "threshold", policy: ThresholdCollision{Threshold: 2}},
+		{spec: "threshold:5", policy: ThresholdCollision{Threshold: 5}},
+		{spec: "fight", policy: FightCollision{}},
+		{spec: "last-standing", policy: LastAlienStandingCollision{}},
+		{spec: "threshold:0", withErr: true},
+		{spec: "threshold:many", withErr: true},
+		{spec: "fight:2", withErr: true},
+		{spec: "peace", withErr: true},
+	}
+	for _, val := range vals {
+		s.Run(val.spec, func() {
+			policy, err := ParseCollisionPolicy(val.spec)
+			if val.withErr {
+				s.ErrorIs(err, ErrorInvalidCollisionPolicy)
+			} else {
+				s.Nil(err)
+				s.Equal(val.policy, policy)
+			}
+		})
+	}
+}
+
+// buildStarSim builds a simulator where every alien can only move to the city "center".
+func buildStarSim(numAliens int, policy CollisionPolicy) AlienSimulator {
+	m := Map[City, Direction]{
+		Cities: CityStore{},
+		Graph:  graph.NewGraph[City, Direction](),
+	}
+	m.getOrCreateCity("center")
+	aliens := []*Alien{}
+	for i := 0; i < numAliens; i++ {
+		name := string(rune('a' + i))
+		m.getOrCreateCity(name)
+		m.AddPath(name, "center", "north")
+		alien := NewAlien(i, name, name, &m)
+		aliens = append(aliens, &alien)
+	}
+	sim := NewAlienSimulator(&m, aliens, 50, false)
+	sim.Sink = nil
+	sim.Collision = policy
+	return sim
+}
+
+func (s *CollisionTestSuite) TestSimulateCollisionPolicies() {
+	vals := []struct {
+		name          string
+		sim           AlienSimulator
+		numDead       int
+		cityDestroyed bool
+		reason        StopReason
+	}{
+		{
+			name:          "threshold of 3 aliens",
+			sim:           buildStarSim(3, ThresholdCollision{Threshold: 3}),
+			numDead:       3,
+			cityDestroyed: true,
+			reason:        StopAllAliensDead,
+		},
+		{
+			name:          "threshold higher than the number of aliens",
+			sim:           buildStarSim(3, ThresholdCollision{Threshold: 4}),
+			numDead:       0,
+			cityDestroyed: false,
+			reason:        StopAllAliensMaxMoves,
+		},
+		{
+			name:          "fight leaves one survivor",
+			sim:           buildStarSim(4, FightCollision{}),
+			numDead:       3,
+			cityDestroyed: false,
+			reason:        StopAllAliensDone,
+		},
+		{
+			name:          "last alien standing",
+			sim:           buildStarSim(4, LastAlienStandingCollision{}),
+			numDead:       3,
+			cityDestroyed: false,
+			reason:        StopLastAlienStanding,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sim := val.sim
+			s.Nil(sim.SimulateInvasion())
+			s.Equal(val.numDead, sim.NumDeadAliens)
+			_, exists := sim.Map.Cities["center"]
+			s.Equal(!val.cityDestroyed, exists)
+			s.Equal(val.reason, sim.StopReason)
+			center := sim.Map.graphCity("center")
+			if !val.cityDestroyed {
+				s.Equal(len(sim.Aliens)-val.numDead, len(center.Aliens))
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrorInvalidDirectionSet = errors.New("Invalid direction set.")
+
+// DirectionsHeader starts the map file line declaring the directions of the map, like '#directions: compass,updown'.
+// The header needs to come before the first city.
+const DirectionsHeader = "#directions:"
+
+// DirectionSet is the vocabulary of directions roads can take. Directions can have an inverse, used to build the
+// road back on symmetric maps.
+type DirectionSet struct {
+	// Directions are the valid directions in declaration order.
+	Directions []Direction
+	// Inverses maps a direction to its opposite one. Directions without an inverse are missing.
+	Inverses map[Direction]Direction
+}
+
+// namedDirectionSets are the sets that can be used by name on a direction set spec.
+var namedDirectionSets = map[string]string{
+	"compass":  "north:south,east:west",
+	"compass8": "north:south,east:west,northeast:southwest,northwest:southeast",
+	"updown":   "up:down",
+}
+
+// DefaultDirections is the set used by maps that do not declare one: the four compass points.
+var DefaultDirections = mustParseDirectionSet("compass")
+
+// DirectionSetNames returns the names of the predefined direction sets.
+func DirectionSetNames() []string {
+	return []string{"compass", "compass8", "updown"}
+}
+
+// ParseDirectionSet creates a direction set from a comma separated list of predefined set names and direction
+// labels. Labels can have an inverse as 'label:inverse', like 'compass,updown' or 'fore:aft,port:starboard,hatch'.
+func ParseDirectionSet(spec string) (*DirectionSet, error) {
+	set := &DirectionSet{Inverses: map[Direction]Direction{}}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if named, ok := namedDirectionSets[part]; ok {
+			part = named
+		}
+		for _, label := range strings.Split(part, ",") {
+			dir, inverse, hasInverse := strings.Cut(label, ":")
+			if err := set.add(Direction(dir), Direction(inverse), hasInverse); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return set, nil
+}
+
+func mustParseDirectionSet(spec string) *DirectionSet {
+	set, err := ParseDirectionSet(spec)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// validDirectionLabel checks a direction can be written on a map file.
+func validDirectionLabel(dir Direction) error {
+	if dir == "" {
+		return fmt.Errorf("%w Directions cannot be empty", ErrorInvalidDirectionSet)
+	}
+	if strings.ContainsAny(string(dir), " \t\r\n=:,#") {
+		return fmt.Errorf("%w Direction '%s' cannot have spaces or any of '=:,#'", ErrorInvalidDirectionSet, dir)
+	}
+	return nil
+}
+
+// add adds a direction and its inverse to the set. Adding a direction again is only valid with the same inverse.
+func (ds *DirectionSet) add(dir, inverse Direction, hasInverse bool) error {
+	if err := validDirectionLabel(dir); err != nil {
+		return err
+	}
+	if hasInverse {
+		if err := validDirectionLabel(inverse); err != nil {
+			return err
+		}
+		for _, pair := range [][2]Direction{{dir, inverse}, {inverse, dir}} {
+			if current, ok := ds.Inverses[pair[0]]; ok && current != pair[1] {
+				return fmt.Errorf("%w '%s' cannot be the inverse of '%s' and '%s'", ErrorInvalidDirectionSet, pair[0], current, pair[1])
+			}
+		}
+	} else if current, ok := ds.Inverses[dir]; ok {
+		return fmt.Errorf("%w '%s' is declared without inverse and with inverse '%s'", ErrorInvalidDirectionSet, dir, current)
+	}
+	ds.append(dir)
+	if hasInverse {
+		ds.append(inverse)
+		ds.Inverses[dir] = inverse
+		ds.Inverses[inverse] = dir
+	}
+	return nil
+}
+
+// append adds the direction when it is not on the set.
+func (ds *DirectionSet) append(dir Direction) {
+	if !ds.Contains(dir) {
+		ds.Directions = append(ds.Directions, dir)
+	}
+}
+
+// Contains tells if the direction is on the set.
+func (ds *DirectionSet) Contains(dir Direction) bool {
+	for _, d := range ds.Directions {
+		if d == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// Inverse returns the opposite direction, or an empty direction when it has none.
+func (ds *DirectionSet) Inverse(dir Direction) Direction {
+	return ds.Inverses[dir]
+}
+
+// Names returns the directions of the set as strings in declaration order.
+func (ds *DirectionSet) Names() []string {
+	names := make([]string, 0, len(ds.Directions))
+	for _, d := range ds.Directions {
+		names = append(names, string(d))
+	}
+	return names
+}
+
+// String returns the set as a spec ParseDirectionSet can read, with every inverse pair once.
+func (ds *DirectionSet) String() string {
+	parts := []string{}
+	written := map[Direction]bool{}
+	for _, d := range ds.Directions {
+		if written[d] {
+			continue
+		}
+		written[d] = true
+		if inverse, ok := ds.Inverses[d]; ok {
+			written[inverse] = true
+			parts = append(parts, fmt.Sprintf("%s:%s", d, inverse))
+		} else {
+			parts = append(parts, string(d))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// directionHeader returns the spec of a '#directions:' header line.
+func directionHeader(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, DirectionsHeader) {
+		return "", false
+	}
+	return strings.TrimSpace(line[len(DirectionsHeader):]), true
+}
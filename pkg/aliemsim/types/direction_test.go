@@ -0,0 +1,111 @@
+package types
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type DirectionTestSuite struct {
+	suite.Suite
+}
+
+func TestDirectionTestSuite(t *testing.T) {
+	suite.Run(t, &DirectionTestSuite{})
+}
+
+func (s *DirectionTestSuite) TestParseDirectionSet() {
+	vals := []struct {
+		name     string
+		spec     string
+		names    []string
+		inverses map[Direction]Direction
+		str      string
+		err      error
+	}{
+		{
+			name:     "compass",
+			spec:     "compass",
+			names:    []string{"north", "south", "east", "west"},
+			inverses: map[Direction]Direction{"north": "south", "south": "north", "east": "west", "west": "east"},
+			str:      "north:south,east:west",
+		},
+		{
+			name:  "compass with up and down",
+			spec:  "compass, updown",
+			names: []string{"north", "south", "east", "west", "up", "down"},
+			inverses: map[Direction]Direction{
+				"north": "south", "south": "north", "east": "west", "west": "east", "up": "down", "down": "up",
+			},
+			str: "north:south,east:west,up:down",
+		},
+		{
+			name:     "free form labels",
+			spec:     "fore:aft,hatch,port:starboard",
+			names:    []string{"fore", "aft", "hatch", "port", "starboard"},
+			inverses: map[Direction]Direction{"fore": "aft", "aft": "fore", "port": "starboard", "starboard": "port"},
+			str:      "fore:aft,hatch,port:starboard",
+		},
+		{
+			name:     "sets overlapping with the same inverses",
+			spec:     "compass,compass8,north:south",
+			names:    []string{"north", "south", "east", "west", "northeast", "southwest", "northwest", "southeast"},
+			inverses: mustParseDirectionSet("compass8").Inverses,
+			str:      "north:south,east:west,northeast:southwest,northwest:southeast",
+		},
+		{
+			name: "conflicting inverses",
+			spec: "compass,north:up",
+			err:  ErrorInvalidDirectionSet,
+		},
+		{
+			name: "direction without inverse after one with",
+			spec: "updown,up",
+			err:  ErrorInvalidDirectionSet,
+		},
+		{
+			name: "empty label",
+			spec: "compass,",
+			err:  ErrorInvalidDirectionSet,
+		},
+		{
+			name: "label with an equal sign",
+			spec: "a=b",
+			err:  ErrorInvalidDirectionSet,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			set, err := ParseDirectionSet(val.spec)
+			if val.err != nil {
+				s.ErrorIs(err, val.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(val.names, set.Names())
+			s.Equal(val.inverses, set.Inverses)
+			s.Equal(val.str, set.String())
+			again, err := ParseDirectionSet(set.String())
+			s.Nil(err)
+			s.Equal(set, again)
+		})
+	}
+}
+
+func (s *DirectionTestSuite) TestDefaultDirections() {
+	s.Equal(ValidDirections, DefaultDirections.Names())
+	for _, dir := range ValidDirections {
+		s.Equal(InverseMapper(Direction(dir)), DefaultDirections.Inverse(Direction(dir)))
+	}
+	s.False(DefaultDirections.Contains("up"))
+	s.Equal(Direction(""), DefaultDirections.Inverse("up"))
+}
+
+func (s *DirectionTestSuite) TestDirectionHeader() {
+	spec, ok := directionHeader("  #directions:  compass,updown ")
+	s.True(ok)
+	s.Equal("compass,updown", spec)
+	_, ok = directionHeader("# directions: compass")
+	s.False(ok)
+	_, ok = directionHeader("Foo north=Bar")
+	s.False(ok)
+}
@@ -0,0 +1,213 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// EventType identifies what happened on a simulation event.
+type EventType string
+
+const (
+	EventAlienSpawned         EventType = "alien_spawned"
+	EventAlienMoved           EventType = "alien_moved"
+	EventAlienDeparted        EventType = "alien_departed"
+	EventAlienTrapped         EventType = "alien_trapped"
+	EventAlienStayed          EventType = "alien_stayed"
+	EventCityDestroyed        EventType = "city_destroyed"
+	EventRoadDestroyed        EventType = "road_destroyed"
+	EventAliensFought         EventType = "aliens_fought"
+	EventAlienReachedMaxMoves EventType = "alien_reached_max_moves"
+	EventSimulationEnded      EventType = "simulation_ended"
+)
+
+// StopReason tells why a simulation ended.
+type StopReason string
+
+const (
+	StopNoCities          StopReason = "no_cities"
+	StopAllAliensDead     StopReason = "all_aliens_dead"
+	StopAllAliensMaxMoves StopReason = "all_aliens_reached_max_moves"
+	StopAllAliensDone     StopReason = "all_aliens_done"
+)
+
+// CauseAlienFight is the destruction cause of a city where aliens fought.
+const CauseAlienFight = "alien_fight"
+
+// CauseRoadWornOut is the destruction cause of a road that ran out of durability.
+const CauseRoadWornOut = "worn_out"
+
+// CauseRoadFailure is the destruction cause of a road that failed after a traversal.
+const CauseRoadFailure = "road_failure"
+
+// Event is something that happened during a simulation. Only the fields that make sense for the Type are set.
+type Event struct {
+	Type         EventType
+	Iteration    int
+	AlienID      int
+	AlienName    string
+	NumMovements int
+	FromCity     string
+	ToCity       string
+	Direction    Direction
+	City         string
+	Aliens       []string
+	Dead         []string
+	Cause        string
+	Reason       StopReason
+	// Arrival is the iteration an alien that departed on a long road arrives to its end.
+	Arrival int
+	// Summary is the human readable final map and stats of a SimulationEnded event.
+	Summary string
+}
+
+// EventSink receives the events emitted by an AlienSimulator.
+type EventSink interface {
+	Emit(event Event)
+}
+
+// MultiSink sends every event to all of its sinks in order.
+type MultiSink []EventSink
+
+// Emit sends the event to every sink.
+func (ms MultiSink) Emit(event Event) {
+	for _, sink := range ms {
+		sink.Emit(event)
+	}
+}
+
+// LogSink writes events with the standard logger. Events about single aliens are only logged when Verbose is set.
+type LogSink struct {
+	Verbose bool
+}
+
+// Emit logs a human readable line for the event.
+func (ls LogSink) Emit(event Event) {
+	switch event.Type {
+	case EventCityDestroyed:
+		log.Printf("[DESTROYED] Aliens %s are fighting! City %s is destroyed.", strings.Join(event.Aliens, " and "), event.City)
+	case EventRoadDestroyed:
+		message := fmt.Sprintf("[ROAD DESTROYED] Road %s from %s to %s collapsed (%s).", event.Direction, event.FromCity, event.ToCity, event.Cause)
+		if len(event.Dead) > 0 {
+			message += fmt.Sprintf(" %s died on it.", strings.Join(event.Dead, " and "))
+		}
+		log.Print(message)
+	case EventAliensFought:
+		place := event.City
+		if place == "" {
+			place = fmt.Sprintf("the road between %s and %s", event.FromCity, event.ToCity)
+		}
+		log.Printf("[FIGHT] Aliens %s fought on %s! %s died.", strings.Join(event.Aliens, " and "), place, strings.Join(event.Dead, " and "))
+	case EventSimulationEnded:
+		log.Print(stopReasonMessage(event.Reason))
+		if event.Summary != "" {
+			log.Print(event.Summary)
+		}
+	}
+	if !ls.Verbose {
+		return
+	}
+	switch event.Type {
+	case EventAlienSpawned:
+		log.Printf("Alien %s landed on %s.", event.AlienName, event.City)
+	case EventAlienMoved:
+		log.Printf("Alien %s moved %s from %s to %s. [Movement #%d]", event.AlienName, event.Direction, event.FromCity, event.ToCity, event.NumMovements)
+	case EventAlienDeparted:
+		log.Printf("Alien %s left %s going %s to %s, arriving on iteration %d.", event.AlienName, event.FromCity, event.Direction, event.ToCity, event.Arrival)
+	case EventAlienTrapped:
+		log.Printf("Alien %s is trapped on %v! [Movement #%d]", event.AlienName, event.City, event.NumMovements)
+	case EventAlienStayed:
+		log.Printf("Alien %s stays on %v. [Movement #%d]", event.AlienName, event.City, event.NumMovements)
+	case EventAlienReachedMaxMoves:
+		log.Printf("Alien %s reached the max number of moves on %s.", event.AlienName, event.City)
+	}
+}
+
+// jsonEvent is the JSON representation of an Event. Alien fields are only present on alien events.
+type jsonEvent struct {
+	Type         EventType  `json:"type"`
+	Iteration    int        `json:"iteration"`
+	AlienID      *int       `json:"alien_id,omitempty"`
+	AlienName    string     `json:"alien_name,omitempty"`
+	NumMovements *int       `json:"num_movements,omitempty"`
+	FromCity     string     `json:"from_city,omitempty"`
+	ToCity       string     `json:"to_city,omitempty"`
+	Direction    Direction  `json:"direction,omitempty"`
+	City         string     `json:"city,omitempty"`
+	Aliens       []string   `json:"aliens,omitempty"`
+	Dead         []string   `json:"dead,omitempty"`
+	Cause        string     `json:"cause,omitempty"`
+	Reason       StopReason `json:"reason,omitempty"`
+	Arrival      int        `json:"arrival,omitempty"`
+}
+
+// JSONLinesSink writes every event as a JSON object on its own line.
+type JSONLinesSink struct {
+	encoder *json.Encoder
+	err     error
+}
+
+// NewJSONLinesSink creates a sink writing JSON lines into w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{encoder: json.NewEncoder(w)}
+}
+
+// Emit writes the event as a JSON line. After the first write error the following events are dropped.
+func (js *JSONLinesSink) Emit(event Event) {
+	if js.err != nil {
+		return
+	}
+	je := jsonEvent{
+		Type:      event.Type,
+		Iteration: event.Iteration,
+		FromCity:  event.FromCity,
+		ToCity:    event.ToCity,
+		Direction: event.Direction,
+		City:      event.City,
+		Aliens:    event.Aliens,
+		Dead:      event.Dead,
+		Cause:     event.Cause,
+		Reason:    event.Reason,
+		Arrival:   event.Arrival,
+	}
+	if event.AlienName != "" {
+		id, movements := event.AlienID, event.NumMovements
+		je.AlienID = &id
+		je.AlienName = event.AlienName
+		je.NumMovements = &movements
+	}
+	js.err = js.encoder.Encode(je)
+}
+
+// Err returns the first error found writing events.
+func (js *JSONLinesSink) Err() error {
+	return js.err
+}
+
+// stopReasonMessage returns the log message for a stop reason.
+func stopReasonMessage(reason StopReason) string {
+	switch reason {
+	case StopNoCities:
+		return "No more cities left. Stopping simulation."
+	case StopAllAliensDead:
+		return "All aliens are dead. Stopping simulation."
+	case StopAllAliensMaxMoves:
+		return "All aliens have reached the max number of moves. Stopping simulation."
+	case StopAllAliensDone:
+		return "All aliens done. Stopping simulation."
+	case StopLastAlienStanding:
+		return "Only one alien is alive. Stopping simulation."
+	case StopCitiesDestroyed:
+		return "Enough cities are destroyed. Stopping simulation."
+	case StopAliensDead:
+		return "Enough aliens are dead. Stopping simulation."
+	case StopCitiesLeft:
+		return "Few cities are left. Stopping simulation."
+	case StopAliensAlive:
+		return "Few aliens are alive. Stopping simulation."
+	}
+	return "Stopping simulation."
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type EventsTestSuite struct {
+	suite.Suite
+}
+
+// recordingSink stores every event it receives.
+type recordingSink struct {
+	events []Event
+}
+
+func (r *recordingSink) Emit(event Event) {
+	r.events = append(r.events, event)
+}
+
+func (r *recordingSink) ofType(eventType EventType) []Event {
+	result := []Event{}
+	for _, e := range r.events {
+		if e.Type == eventType {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, &EventsTestSuite{})
+}
+
+func (s *EventsTestSuite) TestMultiSink() {
+	sink1 := &recordingSink{}
+	sink2 := &recordingSink{}
+	multi := MultiSink{sink1, sink2}
+	multi.Emit(Event{Type: EventAlienMoved})
+	multi.Emit(Event{Type: EventSimulationEnded})
+	s.Equal(sink1.events, sink2.events)
+	s.Equal(len(sink1.events), 2)
+}
+
+func (s *EventsTestSuite) TestJSONLinesSink() {
+	buf := &bytes.Buffer{}
+	sink := NewJSONLinesSink(buf)
+	sink.Emit(Event{Type: EventAlienMoved, Iteration: 3, AlienID: 0, AlienName: "alien", NumMovements: 2, FromCity: "a", ToCity: "b", Direction: "north"})
+	sink.Emit(Event{Type: EventCityDestroyed, Iteration: 4, City: "b", Aliens: []string{"alien", "alien2"}, Cause: CauseAlienFight})
+	sink.Emit(Event{Type: EventSimulationEnded, Iteration: 4, Reason: StopAllAliensDead})
+	s.Nil(sink.Err())
+	s.Equal(`{"type":"alien_moved","iteration":3,"alien_id":0,"alien_name":"alien","num_movements":2,"from_city":"a","to_city":"b","direction":"north"}
+{"type":"city_destroyed","iteration":4,"city":"b","aliens":["alien","alien2"],"cause":"alien_fight"}
+{"type":"simulation_ended","iteration":4,"reason":"all_aliens_dead"}
+`, buf.String())
+}
+
+func (s *EventsTestSuite) TestSimulationEvents() {
+	vals := []struct {
+		name       string
+		simBuilder func() AlienSimulator
+		counts     map[EventType]int
+		reason     StopReason
+	}{
+		{
+			name: "invasion with no cities",
+			simBuilder: func() AlienSimulator {
+				m := Map[City, Direction]{Cities: CityStore{}}
+				return NewAlienSimulator(&m, []*Alien{{Name: "alien"}}, 10, false)
+			},
+			counts: map[EventType]int{
+				EventAlienSpawned:    0,
+				EventSimulationEnded: 1,
+			},
+			reason: StopNoCities,
+		},
+		{
+			name: "two aliens fighting on the only reachable city",
+			simBuilder: func() AlienSimulator {
+				m := Map[City, Direction]{
+					Cities: CityStore{},
+					Graph:  graph.NewGraph[City, Direction](),
+				}
+				m.getOrCreateCity("a")
+				m.getOrCreateCity("b")
+				m.getOrCreateCity("c")
+				m.AddPath("a", "c", "north")
+				m.AddPath("b", "c", "south")
+				aliens := []*Alien{{Name: "alien1", ID: 0, CurrentCityName: "a", Map: &m}, {Name: "alien2", ID: 1, CurrentCityName: "b", Map: &m}}
+				return NewAlienSimulator(&m, aliens, 100, false)
+			},
+			counts: map[EventType]int{
+				EventAlienSpawned:    2,
+				EventAlienMoved:      2,
+				EventCityDestroyed:   1,
+				EventSimulationEnded: 1,
+			},
+			reason: StopAllAliensDead,
+		},
+		{
+			name: "trapped alien reaching max moves",
+			simBuilder: func() AlienSimulator {
+				m := Map[City, Direction]{
+					Cities: CityStore{},
+					Graph:  graph.NewGraph[City, Direction](),
+				}
+				m.getOrCreateCity("a")
+				alien := NewAlien(0, "alien1", "a", &m)
+				return NewAlienSimulator(&m, []*Alien{&alien}, 3, false)
+			},
+			counts: map[EventType]int{
+				EventAlienSpawned:         1,
+				EventAlienTrapped:         3,
+				EventAlienReachedMaxMoves: 1,
+				EventSimulationEnded:      1,
+			},
+			reason: StopAllAliensMaxMoves,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sink := &recordingSink{}
+			sim := val.simBuilder()
+			sim.Sink = sink
+			s.Nil(sim.SimulateInvasion())
+			for eventType, count := range val.counts {
+				s.Equal(count, len(sink.ofType(eventType)), eventType)
+			}
+			s.Equal(val.reason, sim.StopReason)
+			last := sink.events[len(sink.events)-1]
+			s.Equal(EventSimulationEnded, last.Type)
+			s.Equal(val.reason, last.Reason)
+		})
+	}
+}
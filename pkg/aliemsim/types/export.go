@@ -0,0 +1,166 @@
+package types
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// exportCity is a city drawn by the graph exporters. Ghost cities were destroyed by the invasion.
+type exportCity struct {
+	name  string
+	ghost bool
+}
+
+// exportRoad is a road drawn by the graph exporters. Ghost roads were destroyed with one of their cities.
+type exportRoad struct {
+	from, to string
+	dir      Direction
+	ghost    bool
+}
+
+// exportGraph returns the cities and roads to draw sorted by name. Cities and roads of options.Before that are
+// missing from the map are returned as ghosts.
+func exportGraph(m *Map[City, Direction], options ExportOptions) ([]exportCity, []exportRoad) {
+	cities := []exportCity{}
+	roads := []exportRoad{}
+	source := m
+	if options.Before != nil {
+		source = options.Before
+	}
+	for _, name := range source.GetCitiesNames() {
+		_, alive := m.Cities[name]
+		cities = append(cities, exportCity{name: name, ghost: !alive})
+		paths, _ := source.GetPaths(source.Cities[name])
+		for _, road := range sortedRoads(paths) {
+			alive := m.Graph.GetEdge(road.From.Id(), road.To.Id()) != nil
+			roads = append(roads, exportRoad{from: name, to: road.To.Data.Name, dir: road.Data, ghost: !alive})
+		}
+	}
+	if options.Before != nil {
+		// cities that only exist after the invasion, like the ones added by hand to the map
+		for _, name := range m.GetCitiesNames() {
+			if _, ok := options.Before.Cities[name]; !ok {
+				cities = append(cities, exportCity{name: name})
+			}
+		}
+		sort.Slice(cities, func(i, j int) bool { return cities[i].name < cities[j].name })
+	}
+	return cities, roads
+}
+
+// dotQuote returns s as a quoted DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+const dotGhostStyle = `style=dashed, color=gray, fontcolor=gray`
+
+// WriteDOT writes the map as a Graphviz digraph with directions as edge labels. Destroyed cities and roads are drawn
+// dashed and gray.
+func WriteDOT(w io.Writer, m *Map[City, Direction], options ExportOptions) error {
+	cities, roads := exportGraph(m, options)
+	var b strings.Builder
+	b.WriteString("digraph world {\n")
+	for _, city := range cities {
+		if city.ghost {
+			fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(city.name), dotGhostStyle)
+		} else {
+			fmt.Fprintf(&b, "  %s;\n", dotQuote(city.name))
+		}
+	}
+	for _, road := range roads {
+		attrs := "label=" + dotQuote(string(road.dir))
+		if road.ghost {
+			attrs += ", " + dotGhostStyle
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(road.from), dotQuote(road.to), attrs)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the map as a GraphML directed graph. Roads have a 'direction' data key, and cities and roads
+// have a 'destroyed' data key.
+func WriteGraphML(w io.Writer, m *Map[City, Direction], options ExportOptions) error {
+	cities, roads := exportGraph(m, options)
+	doc := graphMLDocument{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "destroyed", For: "all", AttrName: "destroyed", AttrType: "boolean"},
+			{ID: "direction", For: "edge", AttrName: "direction", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: "world", EdgeDefault: "directed", Nodes: []graphMLNode{}, Edges: []graphMLEdge{}},
+	}
+	for _, city := range cities {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:   city.name,
+			Data: []graphMLData{{Key: "destroyed", Value: fmt.Sprint(city.ghost)}},
+		})
+	}
+	for _, road := range roads {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: road.from,
+			Target: road.to,
+			Data: []graphMLData{
+				{Key: "direction", Value: string(road.dir)},
+				{Key: "destroyed", Value: fmt.Sprint(road.ghost)},
+			},
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func init() {
+	RegisterMapFormat(MapFormat{Name: "dot", Extensions: []string{".dot", ".gv"}, Write: WriteDOT})
+	RegisterMapFormat(MapFormat{Name: "graphml", Extensions: []string{".graphml"}, Write: WriteGraphML})
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, &ExportTestSuite{})
+}
+
+// invadedMaps returns a map and a copy where city Bar was destroyed.
+func invadedMaps() (*Map[City, Direction], *Map[City, Direction]) {
+	text := "Foo north=Bar west=Z\"\nBar south=Foo\n"
+	before, _ := NewMapFromReader(strings.NewReader(text))
+	after, _ := NewMapFromReader(strings.NewReader(text))
+	after.DestroyCity(after.Cities["Bar"])
+	return before, after
+}
+
+func (s *ExportTestSuite) TestWriteDOT() {
+	before, after := invadedMaps()
+	var buf bytes.Buffer
+	s.Nil(WriteMap(&buf, before, "dot", ExportOptions{}))
+	s.Equal(`digraph world {
+  "Bar";
+  "Foo";
+  "Z\"";
+  "Bar" -> "Foo" [label="south"];
+  "Foo" -> "Bar" [label="north"];
+  "Foo" -> "Z\"" [label="west"];
+}
+`, buf.String())
+
+	buf.Reset()
+	s.Nil(WriteMap(&buf, after, "dot", ExportOptions{Before: before}))
+	s.Equal(`digraph world {
+  "Bar" [style=dashed, color=gray, fontcolor=gray];
+  "Foo";
+  "Z\"";
+  "Bar" -> "Foo" [label="south", style=dashed, color=gray, fontcolor=gray];
+  "Foo" -> "Bar" [label="north", style=dashed, color=gray, fontcolor=gray];
+  "Foo" -> "Z\"" [label="west"];
+}
+`, buf.String())
+}
+
+func (s *ExportTestSuite) TestWriteGraphML() {
+	before, after := invadedMaps()
+	var buf bytes.Buffer
+	s.Nil(WriteMap(&buf, after, "graphml", ExportOptions{Before: before}))
+	out := buf.String()
+	s.True(strings.HasPrefix(out, "<?xml"))
+	s.Contains(out, `<graph id="world" edgedefault="directed">`)
+	s.Contains(out, `<node id="Bar">
+      <data key="destroyed">true</data>
+    </node>`)
+	s.Contains(out, `<node id="Z&#34;">`)
+	s.Contains(out, `<edge source="Foo" target="Z&#34;">
+      <data key="direction">west</data>
+      <data key="destroyed">false</data>
+    </edge>`)
+	s.Equal(3, strings.Count(out, "<edge "))
+}
+
+func (s *ExportTestSuite) TestWriteOnlyFormats() {
+	s.Equal("dot", MapFormatForPath("world.gv"))
+	s.Equal("graphml", MapFormatForPath("world.graphml"))
+	_, err := ReadMap(strings.NewReader("digraph {}"), MapOptions{Format: "dot"})
+	s.ErrorIs(err, ErrorUnknownMapFormat)
+}
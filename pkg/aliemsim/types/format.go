@@ -0,0 +1,255 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var ErrorUnknownMapFormat = errors.New("Unknown map format.")
+var ErrorInvalidMapDocument = errors.New("Invalid map document.")
+
+// TextFormat is the name of the 'city dir=city' format, used when no other format matches.
+const TextFormat = "text"
+
+// ExportOptions changes how a map is written.
+type ExportOptions struct {
+	// KeepIsolatedCities writes cities without roads on formats that skip them by default.
+	KeepIsolatedCities bool
+	// Before is the map before the invasion. Graph formats draw its cities and roads missing from the written map
+	// as destroyed ghosts. Can be nil.
+	Before *Map[City, Direction]
+}
+
+// MapFormat reads and writes maps on a file format. Formats only used to draw maps have a nil Read.
+type MapFormat struct {
+	Name string
+	// Extensions are the file extensions of the format including the dot, like '.json'.
+	Extensions []string
+	Read       func(reader io.Reader, options MapOptions) (*Map[City, Direction], error)
+	Write      func(w io.Writer, m *Map[City, Direction], options ExportOptions) error
+}
+
+var mapFormats = map[string]MapFormat{}
+
+// RegisterMapFormat adds a format to the registry, replacing any format with the same name.
+func RegisterMapFormat(format MapFormat) {
+	mapFormats[format.Name] = format
+}
+
+// MapFormatNames returns the names of the registered formats sorted.
+func MapFormatNames() []string {
+	names := []string{}
+	for name := range mapFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetMapFormat returns the registered format with the given name.
+func GetMapFormat(name string) (MapFormat, error) {
+	format, ok := mapFormats[name]
+	if !ok {
+		return MapFormat{}, fmt.Errorf("%w '%s' needs to be one from %v", ErrorUnknownMapFormat, name, MapFormatNames())
+	}
+	return format, nil
+}
+
+// MapFormatForPath returns the name of the registered format handling the extension of path. Files with unknown
+// extensions use the text format.
+func MapFormatForPath(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, name := range MapFormatNames() {
+		for _, formatExt := range mapFormats[name].Extensions {
+			if ext == formatExt {
+				return name
+			}
+		}
+	}
+	return TextFormat
+}
+
+// ReadMap reads a map on the format named by options.Format, or on the text format when it is empty.
+func ReadMap(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
+	name := options.Format
+	if name == "" {
+		name = TextFormat
+	}
+	format, err := GetMapFormat(name)
+	if err != nil {
+		return nil, err
+	}
+	if format.Read == nil {
+		return nil, fmt.Errorf("%w '%s' can only be written", ErrorUnknownMapFormat, name)
+	}
+	return format.Read(reader, options)
+}
+
+// WriteMap writes the map into w on the named format.
+func WriteMap(w io.Writer, m *Map[City, Direction], name string, options ExportOptions) error {
+	format, err := GetMapFormat(name)
+	if err != nil {
+		return err
+	}
+	return format.Write(w, m, options)
+}
+
+// MapDocument is the structured representation of a map used by the JSON and YAML formats.
+type MapDocument struct {
+	// Directions is the direction set spec of the map, see ParseDirectionSet. Uses DefaultDirections when empty.
+	Directions string         `json:"directions,omitempty" yaml:"directions,omitempty"`
+	Cities     []CityDocument `json:"cities" yaml:"cities"`
+	Roads      []RoadDocument `json:"roads" yaml:"roads"`
+}
+
+// CityDocument is a city with its optional attributes.
+type CityDocument struct {
+	Name       string         `json:"name" yaml:"name"`
+	Attributes map[string]any `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// RoadDocument is a road from a city to another one on a direction.
+type RoadDocument struct {
+	From      string    `json:"from" yaml:"from"`
+	To        string    `json:"to" yaml:"to"`
+	Direction Direction `json:"direction" yaml:"direction"`
+	// Distance is the number of iterations it takes to travel the road. Uses 1 when 0.
+	Distance   int     `json:"distance,omitempty" yaml:"distance,omitempty"`
+	Capacity   int     `json:"capacity,omitempty" yaml:"capacity,omitempty"`
+	Durability int     `json:"durability,omitempty" yaml:"durability,omitempty"`
+	Failure    float64 `json:"failure,omitempty" yaml:"failure,omitempty"`
+}
+
+// attributes returns the road attributes of the document.
+func (rd RoadDocument) attributes() RoadAttributes {
+	return RoadAttributes{Distance: rd.Distance, Capacity: rd.Capacity, Durability: rd.Durability, Failure: rd.Failure}
+}
+
+// NewMapFromDocument builds a map from its structured representation. Roads can name cities missing from the
+// cities list. Only the Symmetric and Directions options apply, and Directions overrides the document directions.
+func NewMapFromDocument(doc MapDocument, options MapOptions) (*Map[City, Direction], error) {
+	directions := options.Directions
+	if directions == nil && doc.Directions != "" {
+		var err error
+		if directions, err = ParseDirectionSet(doc.Directions); err != nil {
+			return nil, fmt.Errorf("%w %v", ErrorInvalidMapDocument, err)
+		}
+	}
+	mapObj := newMap(directions)
+	for i, city := range doc.Cities {
+		if city.Name == "" {
+			return nil, fmt.Errorf("%w city %d needs a name", ErrorInvalidMapDocument, i)
+		}
+		if _, ok := mapObj.Cities[city.Name]; ok {
+			return nil, fmt.Errorf("%w city '%s' is declared twice", ErrorInvalidMapDocument, city.Name)
+		}
+		mapObj.getOrCreateCity(city.Name)
+		mapObj.setCityAttributes(city.Name, city.Attributes)
+	}
+	for i, road := range doc.Roads {
+		if road.From == "" || road.To == "" {
+			return nil, fmt.Errorf("%w road %d needs 'from' and 'to' cities", ErrorInvalidMapDocument, i)
+		}
+		if road.From == road.To {
+			return nil, fmt.Errorf("%w road %d: %v", ErrorInvalidMapDocument, i, ErrorPathToSameCity)
+		}
+		if err := road.attributes().validate(); err != nil {
+			return nil, fmt.Errorf("%w road %d: %v", ErrorInvalidMapDocument, i, err)
+		}
+		mapObj.getOrCreateCity(road.From)
+		mapObj.getOrCreateCity(road.To)
+		if err := mapObj.AddPath(road.From, road.To, road.Direction); err != nil {
+			return nil, fmt.Errorf("%w road %d from '%s' to '%s': %v", ErrorInvalidMapDocument, i, road.From, road.To, err)
+		}
+		mapObj.setRoadAttributes(road.From, road.To, road.attributes())
+	}
+	if options.Symmetric {
+		if err := mapObj.addInverseRoads(); err != nil {
+			return nil, err
+		}
+	}
+	return mapObj, nil
+}
+
+// ToDocument returns the structured representation of the map. Cities and roads are sorted by name.
+func (m *Map[N, E]) ToDocument() MapDocument {
+	doc := MapDocument{Cities: []CityDocument{}, Roads: []RoadDocument{}}
+	if m.Directions != nil {
+		doc.Directions = m.Directions.String()
+	}
+	for _, name := range m.GetCitiesNames() {
+		doc.Cities = append(doc.Cities, CityDocument{Name: name, Attributes: m.Cities[name].Attributes})
+		paths, _ := m.GetPaths(m.Cities[name])
+		for _, road := range sortedRoads(paths) {
+			attrs := m.RoadAttributes(name, road.To.Data.Name)
+			roadDoc := RoadDocument{From: name, To: road.To.Data.Name, Direction: road.Data, Capacity: attrs.Capacity,
+				Durability: attrs.Durability, Failure: attrs.Failure}
+			if attrs.Distance != 1 {
+				roadDoc.Distance = attrs.Distance
+			}
+			doc.Roads = append(doc.Roads, roadDoc)
+		}
+	}
+	return doc
+}
+
+func init() {
+	RegisterMapFormat(MapFormat{
+		Name:       TextFormat,
+		Extensions: []string{".txt"},
+		Read:       NewMapFromReaderWithOptions,
+		Write: func(w io.Writer, m *Map[City, Direction], options ExportOptions) error {
+			text := m.ToText(options.KeepIsolatedCities)
+			if m.Directions != nil {
+				text = fmt.Sprintf("%s %s\n%s", DirectionsHeader, m.Directions, text)
+			}
+			_, err := io.WriteString(w, text)
+			return err
+		},
+	})
+	RegisterMapFormat(MapFormat{
+		Name:       "json",
+		Extensions: []string{".json"},
+		Read: func(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
+			doc := MapDocument{}
+			decoder := json.NewDecoder(reader)
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&doc); err != nil {
+				return nil, fmt.Errorf("%w %v", ErrorInvalidMapDocument, err)
+			}
+			return NewMapFromDocument(doc, options)
+		},
+		Write: func(w io.Writer, m *Map[City, Direction], options ExportOptions) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(m.ToDocument())
+		},
+	})
+	RegisterMapFormat(MapFormat{
+		Name:       "yaml",
+		Extensions: []string{".yaml", ".yml"},
+		Read: func(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
+			doc := MapDocument{}
+			decoder := yaml.NewDecoder(reader)
+			decoder.KnownFields(true)
+			if err := decoder.Decode(&doc); err != nil {
+				return nil, fmt.Errorf("%w %v", ErrorInvalidMapDocument, err)
+			}
+			return NewMapFromDocument(doc, options)
+		},
+		Write: func(w io.Writer, m *Map[City, Direction], options ExportOptions) error {
+			encoder := yaml.NewEncoder(w)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(m.ToDocument()); err != nil {
+				return err
+			}
+			return encoder.Close()
+		},
+	})
+}
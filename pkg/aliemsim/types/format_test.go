@@ -0,0 +1,137 @@
+package types
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type FormatTestSuite struct {
+	suite.Suite
+}
+
+func TestFormatTestSuite(t *testing.T) {
+	suite.Run(t, &FormatTestSuite{})
+}
+
+func (s *FormatTestSuite) TestMapFormatForPath() {
+	s.Equal("json", MapFormatForPath("world.json"))
+	s.Equal("yaml", MapFormatForPath("/maps/world.YML"))
+	s.Equal("yaml", MapFormatForPath("world.yaml"))
+	s.Equal(TextFormat, MapFormatForPath("world.txt"))
+	s.Equal(TextFormat, MapFormatForPath("world"))
+	_, err := GetMapFormat("xml")
+	s.ErrorIs(err, ErrorUnknownMapFormat)
+	_, err = ReadMap(strings.NewReader(""), MapOptions{Format: "xml"})
+	s.ErrorIs(err, ErrorUnknownMapFormat)
+}
+
+func (s *FormatTestSuite) TestReadMap() {
+	vals := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{
+			name:   "json",
+			format: "json",
+			input: `{"cities": [{"name": "Foo", "attributes": {"population": 120}}, {"name": "Lonely"}],
+				"roads": [{"from": "Foo", "to": "Bar", "direction": "north"}, {"from": "Bar", "to": "Foo", "direction": "south"}]}`,
+		},
+		{
+			name:   "yaml",
+			format: "yaml",
+			input: `cities:
+  - name: Foo
+    attributes:
+      population: 120
+  - name: Lonely
+roads:
+  - {from: Foo, to: Bar, direction: north}
+  - {from: Bar, to: Foo, direction: south}
+`,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			m, err := ReadMap(strings.NewReader(val.input), MapOptions{Format: val.format})
+			s.Nil(err)
+			s.Equal([]string{"Bar", "Foo", "Lonely"}, m.GetCitiesNames())
+			s.Equal("Bar south=Foo\nFoo north=Bar\nLonely\n", m.ToText(true))
+			s.EqualValues(120, m.Cities["Foo"].Attributes["population"])
+			s.EqualValues(120, m.graphCity("Foo").Attributes["population"])
+			s.Nil(m.Cities["Bar"].Attributes)
+		})
+	}
+}
+
+func (s *FormatTestSuite) TestReadMapErrors() {
+	vals := []struct {
+		name  string
+		input string
+	}{
+		{name: "invalid json", input: `{"cities": [`},
+		{name: "unknown field", input: `{"towns": []}`},
+		{name: "city without name", input: `{"cities": [{"attributes": {"a": 1}}]}`},
+		{name: "city declared twice", input: `{"cities": [{"name": "Foo"}, {"name": "Foo"}]}`},
+		{name: "road to the same city", input: `{"roads": [{"from": "Foo", "to": "Foo", "direction": "north"}]}`},
+		{name: "invalid direction", input: `{"roads": [{"from": "Foo", "to": "Bar", "direction": "up"}]}`},
+		{name: "duplicate road", input: `{"roads": [{"from": "Foo", "to": "Bar", "direction": "north"}, {"from": "Foo", "to": "Bar", "direction": "east"}]}`},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			_, err := ReadMap(strings.NewReader(val.input), MapOptions{Format: "json"})
+			s.ErrorIs(err, ErrorInvalidMapDocument)
+		})
+	}
+	_, err := ReadMap(strings.NewReader(`{"roads": [{"from": "Foo", "to": "Bar", "direction": "north"}, {"from": "Bar", "to": "Foo", "direction": "east"}]}`),
+		MapOptions{Format: "json", Symmetric: true})
+	s.ErrorIs(err, ErrorSymmetricConflict)
+}
+
+func (s *FormatTestSuite) TestWriteMapRoundTrip() {
+	text := "Bar south=Foo west=Qux\nFoo north=Bar\nQux east=Bar\n"
+	original, err := NewMapFromReader(strings.NewReader(text))
+	s.Nil(err)
+	original.setCityAttributes("Foo", map[string]any{"capital": true})
+	for _, format := range MapFormatNames() {
+		if f, _ := GetMapFormat(format); f.Read == nil {
+			continue
+		}
+		s.Run(format, func() {
+			var buf bytes.Buffer
+			s.Nil(WriteMap(&buf, original, format, ExportOptions{}))
+			parsed, err := ReadMap(&buf, MapOptions{Format: format})
+			s.Nil(err)
+			s.Equal(text, parsed.ToString())
+			if format != TextFormat {
+				s.Equal(original.ToDocument(), parsed.ToDocument())
+			}
+		})
+	}
+	s.ErrorIs(WriteMap(&bytes.Buffer{}, original, "xml", ExportOptions{}), ErrorUnknownMapFormat)
+}
+
+func (s *FormatTestSuite) TestWriteMapDirections() {
+	text := "#directions: north:south,east:west,up:down\nDeck1 up=Deck2\nDeck2 down=Deck1\n"
+	original, err := NewMapFromReader(strings.NewReader(text))
+	s.Nil(err)
+	for _, format := range []string{TextFormat, "json", "yaml"} {
+		s.Run(format, func() {
+			var buf bytes.Buffer
+			s.Nil(WriteMap(&buf, original, format, ExportOptions{}))
+			if format == TextFormat {
+				s.Equal(text, buf.String())
+			}
+			parsed, err := ReadMap(&buf, MapOptions{Format: format})
+			s.Nil(err)
+			s.Equal(original.Directions, parsed.Directions)
+			s.Equal(Direction("up"), parsed.DirectionInverseMapper("down"))
+		})
+	}
+	_, err = ReadMap(strings.NewReader(`{"directions": "up:down", "roads": [{"from": "A", "to": "B", "direction": "up"}]}`), MapOptions{Format: "json"})
+	s.Nil(err)
+	_, err = ReadMap(strings.NewReader(`{"directions": "up:down,up:left"}`), MapOptions{Format: "json"})
+	s.ErrorIs(err, ErrorInvalidMapDocument)
+}
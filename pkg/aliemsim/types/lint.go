@@ -0,0 +1,249 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Severity tells how bad a lint diagnostic is.
+type Severity int
+
+const (
+	// SeverityWarning is a problem the simulation can run with, but probably is a mistake on the map.
+	SeverityWarning Severity = iota + 1
+	// SeverityError is a problem that makes the map fail to load or be ambiguous.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic is a problem found on a map file. Line and Column start at 1.
+type Diagnostic struct {
+	Line     int
+	Column   int
+	Severity Severity
+	Code     string
+	Message  string
+}
+
+// Format returns the diagnostic as 'file:line:column: severity: message [code]'.
+func (d Diagnostic) Format(fileName string) string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", fileName, d.Line, d.Column, d.Severity, d.Message, d.Code)
+}
+
+// MaxSeverity returns the highest severity of the diagnostics, or 0 when there are none.
+func MaxSeverity(diagnostics []Diagnostic) Severity {
+	var max Severity
+	for _, d := range diagnostics {
+		if d.Severity > max {
+			max = d.Severity
+		}
+	}
+	return max
+}
+
+// lintRoad is a road declared on a map file.
+type lintRoad struct {
+	from, to string
+	dir      Direction
+	line     int
+	column   int
+}
+
+// cutOffCities returns the cities outside the largest group of cities linked by roads on any direction, aliens on
+// the rest of the map never reach them. Ties go to the group with the first city name.
+func cutOffCities(cities map[string]lineToken, roads []lintRoad) []string {
+	g := graph.NewGraph[City, Direction]()
+	for city := range cities {
+		g.AddVertex(NewCityFromName(city))
+	}
+	for _, road := range roads {
+		_, _ = g.AddEdge(graph.VertexID(road.from), graph.VertexID(road.to), road.dir)
+	}
+	components := g.WeaklyConnectedComponents()
+	largest := 0
+	for i, component := range components {
+		if len(component) > len(components[largest]) {
+			largest = i
+		}
+	}
+	cutOff := []string{}
+	for i, component := range components {
+		if i == largest {
+			continue
+		}
+		for _, id := range component {
+			cutOff = append(cutOff, string(id))
+		}
+	}
+	return cutOff
+}
+
+// LintMap checks a map on the text format and returns every problem found sorted by position. Unlike
+// NewMapFromReader it does not stop on the first problem. Only read errors are returned as error. The options
+// decide the line length limit and comments, and with Symmetric missing roads back are not a problem while roads
+// back on the wrong direction are errors. Directions come from options.Directions or the '#directions:' header.
+func LintMap(reader io.Reader, options MapOptions) ([]Diagnostic, error) {
+	diagnostics := []Diagnostic{}
+	report := func(line, column int, severity Severity, code, format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line: line, Column: column, Severity: severity, Code: code, Message: fmt.Sprintf(format, args...),
+		})
+	}
+	declared := map[string]int{}
+	firstSeen := map[string]lineToken{}
+	firstSeenLine := map[string]int{}
+	seen := func(city string, token lineToken, line int) {
+		if _, ok := firstSeen[city]; !ok {
+			firstSeen[city] = token
+			firstSeenLine[city] = line
+		}
+	}
+	roads := []lintRoad{}
+	byDirection := map[string]map[Direction]lintRoad{}
+	byDestination := map[string]map[string]lintRoad{}
+	directions := DefaultDirections
+	if options.Directions != nil {
+		directions = options.Directions
+	}
+
+	lines := newLineReader(reader, options.maxLineLength())
+	for {
+		textLine, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		lineNum := lines.line
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			report(lineNum, parseErr.Column, SeverityError, "line-too-long", "line is longer than %d bytes", options.maxLineLength())
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if spec, ok := directionHeader(textLine); ok && len(declared) == 0 {
+			if options.Directions != nil {
+				continue
+			}
+			set, err := ParseDirectionSet(spec)
+			if err != nil {
+				report(lineNum, 1, SeverityError, "invalid-directions", "%v", err)
+				continue
+			}
+			directions = set
+			continue
+		}
+		tokens := splitTokens(options.stripComment(textLine))
+		if len(tokens) == 0 && options.Comments {
+			continue
+		}
+		if len(tokens) == 0 {
+			report(lineNum, 1, SeverityError, "syntax", "empty line, every line needs at least a city name")
+			continue
+		}
+		from := tokens[0]
+		if strings.Contains(from.text, "=") {
+			report(lineNum, from.column, SeverityError, "syntax", "line needs to start with a city name, got '%s'", from.text)
+			continue
+		}
+		if prev, ok := declared[from.text]; ok {
+			report(lineNum, from.column, SeverityWarning, "duplicate-city", "city '%s' is already declared on line %d", from.text, prev)
+		} else {
+			declared[from.text] = lineNum
+		}
+		seen(from.text, from, lineNum)
+		if byDirection[from.text] == nil {
+			byDirection[from.text] = map[Direction]lintRoad{}
+			byDestination[from.text] = map[string]lintRoad{}
+		}
+
+		for _, token := range tokens[1:] {
+			dirStr, target, ok := strings.Cut(token.text, "=")
+			to, _, offset, err := parseRoadTarget(target)
+			if !ok || to == "" || strings.Contains(to, "=") {
+				report(lineNum, token.column, SeverityError, "syntax", "road '%s' needs to have format 'direction=city'", token.text)
+				continue
+			}
+			if errors.Is(err, ErrorInvalidDistance) {
+				report(lineNum, token.column+len(dirStr)+1+offset, SeverityError, "invalid-distance", "road '%s' needs a positive distance after the city, like 'north=Bar:3', city names cannot contain ':'", token.text)
+				continue
+			}
+			if err != nil {
+				report(lineNum, token.column+len(dirStr)+1+offset, SeverityError, "invalid-road-attribute", "road '%s': %v", token.text, err)
+				continue
+			}
+			if !directions.Contains(Direction(dirStr)) {
+				report(lineNum, token.column, SeverityError, "invalid-direction", "direction '%s' needs to be one from %v", dirStr, directions.Names())
+				continue
+			}
+			toColumn := token.column + len(dirStr) + 1
+			if to == from.text {
+				report(lineNum, toColumn, SeverityError, "self-loop", "road %s from '%s' leads to itself", dirStr, to)
+				continue
+			}
+			seen(to, lineToken{text: to, column: toColumn}, lineNum)
+			road := lintRoad{from: from.text, to: to, dir: Direction(dirStr), line: lineNum, column: token.column}
+			if prev, ok := byDestination[from.text][to]; ok {
+				report(lineNum, token.column, SeverityError, "duplicate-road", "'%s' already has a road to '%s' on line %d", from.text, to, prev.line)
+				continue
+			}
+			if prev, ok := byDirection[from.text][road.dir]; ok {
+				report(lineNum, token.column, SeverityError, "duplicate-direction", "'%s' already has a road %s to '%s' on line %d", from.text, dirStr, prev.to, prev.line)
+			} else {
+				byDirection[from.text][road.dir] = road
+			}
+			byDestination[from.text][to] = road
+			roads = append(roads, road)
+		}
+	}
+
+	incoming := map[string]int{}
+	for _, road := range roads {
+		incoming[road.to]++
+		back, ok := byDestination[road.to][road.from]
+		if !ok && options.Symmetric {
+			continue
+		}
+		if !ok {
+			report(road.line, road.column, SeverityWarning, "missing-reverse", "road %s from '%s' to '%s' has no road back", road.dir, road.from, road.to)
+			continue
+		}
+		if expected := directions.Inverse(road.dir); expected != "" && back.dir != expected {
+			severity := SeverityWarning
+			if options.Symmetric {
+				severity = SeverityError
+			}
+			report(road.line, road.column, severity, "inconsistent-reverse", "road %s from '%s' to '%s' comes back %s on line %d, expected %s", road.dir, road.from, road.to, back.dir, back.line, expected)
+		}
+	}
+	for city, token := range firstSeen {
+		if incoming[city] == 0 {
+			report(firstSeenLine[city], token.column, SeverityWarning, "no-incoming-road", "no road leads to city '%s'", city)
+		}
+	}
+	for _, city := range cutOffCities(firstSeen, roads) {
+		token := firstSeen[city]
+		report(firstSeenLine[city], token.column, SeverityWarning, "unreachable", "city '%s' cannot be reached from the rest of the map", city)
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		if diagnostics[i].Column != diagnostics[j].Column {
+			return diagnostics[i].Column < diagnostics[j].Column
+		}
+		return diagnostics[i].Code < diagnostics[j].Code
+	})
+	return diagnostics, nil
+}
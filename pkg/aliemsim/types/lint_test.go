@@ -0,0 +1,151 @@
+package types
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type LintTestSuite struct {
+	suite.Suite
+}
+
+func TestLintTestSuite(t *testing.T) {
+	suite.Run(t, &LintTestSuite{})
+}
+
+func (s *LintTestSuite) TestLintMap() {
+	vals := []struct {
+		name        string
+		text        string
+		options     MapOptions
+		diagnostics []string
+	}{
+		{
+			name: "clean map",
+			text: "Foo north=Bar\nBar south=Foo\n",
+		},
+		{
+			name: "syntax errors",
+			text: "Foo north=Bar up=Baz west\nBar south=Foo\n\nnorth=Foo\n",
+			diagnostics: []string{
+				"m:1:15: error: direction 'up' needs to be one from [north south east west] [invalid-direction]",
+				"m:1:22: error: road 'west' needs to have format 'direction=city' [syntax]",
+				"m:3:1: error: empty line, every line needs at least a city name [syntax]",
+				"m:4:1: error: line needs to start with a city name, got 'north=Foo' [syntax]",
+			},
+		},
+		{
+			name: "self loop",
+			text: "Foo  north=Foo",
+			diagnostics: []string{
+				"m:1:1: warning: no road leads to city 'Foo' [no-incoming-road]",
+				"m:1:12: error: road north from 'Foo' leads to itself [self-loop]",
+			},
+		},
+		{
+			name: "duplicate direction and road",
+			text: "Foo north=Bar north=Baz\nFoo west=Bar\nBar south=Foo\nBaz south=Foo\n",
+			diagnostics: []string{
+				"m:1:15: error: 'Foo' already has a road north to 'Bar' on line 1 [duplicate-direction]",
+				"m:2:1: warning: city 'Foo' is already declared on line 1 [duplicate-city]",
+				"m:2:5: error: 'Foo' already has a road to 'Bar' on line 1 [duplicate-road]",
+			},
+		},
+		{
+			name: "reverse roads",
+			text: "Foo north=Bar east=Baz\nBar west=Foo\n",
+			diagnostics: []string{
+				"m:1:5: warning: road north from 'Foo' to 'Bar' comes back west on line 2, expected south [inconsistent-reverse]",
+				"m:1:15: warning: road east from 'Foo' to 'Baz' has no road back [missing-reverse]",
+				"m:2:5: warning: road west from 'Bar' to 'Foo' comes back north on line 1, expected east [inconsistent-reverse]",
+			},
+		},
+		{
+			name:    "comments and long lines",
+			text:    "# header\n\nFoo north=Bar # road\nBar south=Foo north=Baz\nBaz south=Bar\n",
+			options: MapOptions{Comments: true, MaxLineLength: 20},
+			diagnostics: []string{
+				"m:3:1: warning: no road leads to city 'Foo' [no-incoming-road]",
+				"m:3:5: warning: road north from 'Foo' to 'Bar' has no road back [missing-reverse]",
+				"m:4:21: error: line is longer than 20 bytes [line-too-long]",
+				"m:5:1: warning: no road leads to city 'Baz' [no-incoming-road]",
+				"m:5:5: warning: road south from 'Baz' to 'Bar' has no road back [missing-reverse]",
+			},
+		},
+		{
+			name:    "symmetric roads",
+			text:    "Foo north=Bar east=Baz\nBar west=Foo\n",
+			options: MapOptions{Symmetric: true},
+			diagnostics: []string{
+				"m:1:5: error: road north from 'Foo' to 'Bar' comes back west on line 2, expected south [inconsistent-reverse]",
+				"m:2:5: error: road west from 'Bar' to 'Foo' comes back north on line 1, expected east [inconsistent-reverse]",
+			},
+		},
+		{
+			name: "unreachable cities",
+			text: "Foo north=Bar\nBar south=Foo\nLonely\nQux west=Foo\n",
+			diagnostics: []string{
+				"m:3:1: warning: no road leads to city 'Lonely' [no-incoming-road]",
+				"m:3:1: warning: city 'Lonely' cannot be reached from the rest of the map [unreachable]",
+				"m:4:1: warning: no road leads to city 'Qux' [no-incoming-road]",
+				"m:4:5: warning: road west from 'Qux' to 'Foo' has no road back [missing-reverse]",
+			},
+		},
+		{
+			name: "cities cut off from the map",
+			text: "Foo north=Bar\nBar south=Foo north=Baz\nBaz south=Bar\nIsland east=Rock\nRock west=Island\n",
+			diagnostics: []string{
+				"m:4:1: warning: city 'Island' cannot be reached from the rest of the map [unreachable]",
+				"m:4:13: warning: city 'Rock' cannot be reached from the rest of the map [unreachable]",
+			},
+		},
+		{
+			name: "directions header",
+			text: "#directions: updown,hatch\nDeck1 up=Deck2 hatch=Pod north=Bay\nDeck2 up=Deck1\nPod hatch=Deck1\n",
+			diagnostics: []string{
+				"m:2:7: warning: road up from 'Deck1' to 'Deck2' comes back up on line 3, expected down [inconsistent-reverse]",
+				"m:2:26: error: direction 'north' needs to be one from [up down hatch] [invalid-direction]",
+				"m:3:7: warning: road up from 'Deck2' to 'Deck1' comes back up on line 2, expected down [inconsistent-reverse]",
+			},
+		},
+		{
+			name: "road attributes",
+			text: "Foo north=Bar:3 east=Baz:0\nBar south=Foo:3:speed=2 west=Baz:2:capacity=x\n",
+			diagnostics: []string{
+				"m:1:1: warning: no road leads to city 'Foo' [no-incoming-road]",
+				"m:1:5: warning: road north from 'Foo' to 'Bar' has no road back [missing-reverse]",
+				"m:1:26: error: road 'east=Baz:0' needs a positive distance after the city, like 'north=Bar:3', city names cannot contain ':' [invalid-distance]",
+				"m:2:17: error: road 'south=Foo:3:speed=2': Invalid road attribute. 'speed' needs to be one from [distance capacity durability failure] [invalid-road-attribute]",
+				"m:2:36: error: road 'west=Baz:2:capacity=x': Invalid road attribute. capacity 'x' needs to be a number of aliens or traversals, 0 is unlimited [invalid-road-attribute]",
+			},
+		},
+		{
+			name: "invalid directions header",
+			text: "#directions: up=down\nFoo north=Bar\nBar south=Foo\n",
+			diagnostics: []string{
+				"m:1:1: error: Invalid direction set. Direction 'up=down' cannot have spaces or any of '=:,#' [invalid-directions]",
+			},
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			diagnostics, err := LintMap(strings.NewReader(val.text), val.options)
+			s.Nil(err)
+			formatted := []string{}
+			for _, d := range diagnostics {
+				formatted = append(formatted, d.Format("m"))
+			}
+			s.Equal(len(val.diagnostics), len(formatted), formatted)
+			if len(val.diagnostics) > 0 {
+				s.Equal(val.diagnostics, formatted)
+			}
+		})
+	}
+}
+
+func (s *LintTestSuite) TestMaxSeverity() {
+	s.Equal(Severity(0), MaxSeverity([]Diagnostic{}))
+	s.Equal(SeverityWarning, MaxSeverity([]Diagnostic{{Severity: SeverityWarning}}))
+	s.Equal(SeverityError, MaxSeverity([]Diagnostic{{Severity: SeverityWarning}, {Severity: SeverityError}}))
+}
@@ -2,7 +2,6 @@ package types
 
 import (
 	"alien-invasion-simulator/pkg/graph"
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -27,12 +26,17 @@ var ValidDirections = []string{"north", "south", "east", "west"}
 var ErrorCityDoesNotExists = errors.New("City does not exists.")
 var ErrorInvalidDirection = errors.New("Invalid Direction.")
 var ErrorPathToSameCity = errors.New("Cannot create a path to the same city.")
+var ErrorSymmetricConflict = errors.New("Conflicting reverse road.")
 
 // Map represents a set of cities
 type Map[N City, E Direction] struct {
 	Cities                 CityStore
 	DirectionInverseMapper func(Direction) Direction
-	Graph                  graph.Graph[City, Direction]
+	Graph                  *graph.Graph[City, Direction]
+	// Directions are the directions roads can take. Uses DefaultDirections when nil.
+	Directions *DirectionSet
+	// Roads are the attributes of the roads that have any, like a distance. Can be nil.
+	Roads map[graph.EdgeId]RoadAttributes
 }
 
 // InverseMapper mapper of the possible directions to is opposite direction
@@ -46,49 +50,216 @@ func InverseMapper(d Direction) Direction {
 	return inverseDir[d]
 }
 
-// NewMapFromReader create a Map object from the given file reader. Reader should have format: 'city dir=city' per line.
-func NewMapFromReader(reader io.Reader) (*Map[City, Direction], error) {
-	scanner := bufio.NewScanner(reader)
+// MapOptions changes how a map is loaded.
+type MapOptions struct {
+	// Symmetric adds the inverse road of every road using the map DirectionInverseMapper, so 'Foo north=Bar' also
+	// creates 'Bar south=Foo'. Declaring a different road back is an error.
+	Symmetric bool
+	// MaxLineLength is the longest line accepted in bytes. Uses DefaultMaxLineLength when 0.
+	MaxLineLength int
+	// Comments skips blank lines and everything after a '#'.
+	Comments bool
+	// Lenient skips the lines and roads with problems instead of failing. Each problem is sent to OnError.
+	Lenient bool
+	// OnError receives the problems skipped on lenient mode. Can be nil.
+	OnError func(*ParseError)
+	// Format is the name of the registered map format of the input. ReadMap uses the text format when empty.
+	// MaxLineLength, Comments and Lenient only apply to the text format.
+	Format string
+	// Directions overrides the directions declared by the map. Maps without directions use DefaultDirections.
+	Directions *DirectionSet
+}
+
+// newMap creates an empty map with the given directions, or DefaultDirections when nil.
+func newMap(directions *DirectionSet) *Map[City, Direction] {
 	mapObj := &Map[City, Direction]{
-		Cities:                 CityStore{},
-		DirectionInverseMapper: InverseMapper,
-		Graph:                  graph.NewGraph[City, Direction](),
+		Cities:     CityStore{},
+		Graph:      graph.NewGraph[City, Direction](),
+		Directions: directions,
 	}
+	mapObj.DirectionInverseMapper = mapObj.directionSet().Inverse
+	return mapObj
+}
+
+// directionSet returns the directions of the map.
+func (m *Map[N, E]) directionSet() *DirectionSet {
+	if m.Directions == nil {
+		return DefaultDirections
+	}
+	return m.Directions
+}
+
+// Clone returns a deep copy of the map that can be changed without changing the original, like to run many
+// simulations from one parsed map. Cities, roads and the aliens on cities are copied and linked to each other like the
+// originals. Attribute values, alien strategies and the directions are shared.
+func (m *Map[N, E]) Clone() *Map[City, Direction] {
+	clone := &Map[City, Direction]{
+		Cities:                 make(CityStore, len(m.Cities)),
+		DirectionInverseMapper: m.DirectionInverseMapper,
+		Graph:                  m.Graph.Clone(),
+		Directions:             m.Directions,
+	}
+	if m.Roads != nil {
+		clone.Roads = make(map[graph.EdgeId]RoadAttributes, len(m.Roads))
+		for id, road := range m.Roads {
+			clone.Roads[id] = road
+		}
+	}
+	aliens := map[*Alien]*Alien{}
+	for name, city := range m.Cities {
+		cityClone := city.clone(clone, aliens)
+		clone.Cities[name] = &cityClone
+	}
+	clone.Graph.Vertices(func(v *graph.Vertex[City, Direction]) bool {
+		v.Data = v.Data.clone(clone, aliens)
+		if city, ok := clone.Cities[v.Data.Name]; ok {
+			v.Data.Attributes = city.Attributes
+		}
+		return true
+	})
+	return clone
+}
+
+// Snapshot returns an immutable view of the cities and roads of the map. The aliens and attributes of the cities are
+// copied, so the snapshot does not change as the invasion goes on. Copied aliens have no map. The simulator changes
+// cities and aliens without the graph lock, so while it runs read the snapshots it publishes with
+// AlienSimulator.Snapshot instead.
+func (m *Map[N, E]) Snapshot() *graph.Snapshot[City, Direction] {
+	aliens := map[*Alien]*Alien{}
+	return m.Graph.SnapshotFunc(func(city City) City {
+		return city.clone(nil, aliens)
+	})
+}
+
+// NewMapFromReader create a Map object from the given file reader. Reader should have format: 'city dir=city' per line.
+func NewMapFromReader(reader io.Reader) (*Map[City, Direction], error) {
+	return NewMapFromReaderWithOptions(reader, MapOptions{})
+}
 
-	for scanner.Scan() {
-		textLine := scanner.Text()
-		fields := strings.Fields(textLine)
-		err := validTextRow(fields)
+// NewMapFromReaderWithOptions creates a Map object from the given file reader following the options. Problems on
+// the lines of the file are returned as *ParseError. A '#directions:' header before the first city sets the
+// directions of the map unless options.Directions is set.
+func NewMapFromReaderWithOptions(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
+	lines := newLineReader(reader, options.maxLineLength())
+	mapObj := newMap(options.Directions)
+
+	for {
+		textLine, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			if err := options.handle(parseErr); err != nil {
+				return nil, err
+			}
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		var fromCity *City
-		for i, strToken := range fields {
-			if i == 0 {
-				fromCity = mapObj.getOrCreateCity(strToken)
-			} else {
-				err := mapObj.buildPathFromToken(fromCity, strToken)
-				if err != nil {
+		if spec, ok := directionHeader(textLine); ok && len(mapObj.Cities) == 0 {
+			if options.Directions != nil {
+				continue
+			}
+			directions, err := ParseDirectionSet(spec)
+			if err != nil {
+				if err := options.handle(&ParseError{Line: lines.line, Column: 1, Err: err}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			mapObj = newMap(directions)
+			continue
+		}
+		textLine = options.stripComment(textLine)
+		tokens := splitTokens(textLine)
+		if len(tokens) == 0 && options.Comments {
+			continue
+		}
+		if len(tokens) == 0 {
+			if err := options.handle(&ParseError{Line: lines.line, Column: 1, Err: validTextRow(nil, mapObj.directionSet())}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		fromCity := mapObj.getOrCreateCity(tokens[0].text)
+		for _, token := range tokens[1:] {
+			err := validTextRow([]string{fromCity.Name, token.text}, mapObj.directionSet())
+			if err == nil {
+				err = mapObj.buildPathFromToken(fromCity, token.text)
+			}
+			if err != nil {
+				if err := options.handle(&ParseError{Line: lines.line, Column: token.column, Err: err}); err != nil {
 					return nil, err
 				}
 			}
 		}
-
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if options.Symmetric {
+		if err := mapObj.addInverseRoads(); err != nil {
+			return nil, err
+		}
 	}
 	return mapObj, nil
 }
 
-// EdgeToString returns the string representation of an edge
+// addInverseRoads adds the road back of every road on the map that does not have one. Roads back need to go on the
+// inverse direction, and the city cannot already have another road on that direction. Roads on directions without
+// an inverse stay one way.
+func (m *Map[N, E]) addInverseRoads() error {
+	edges := []*graph.Edge[City, Direction]{}
+	for _, edge := range m.Graph.GetEdges() {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From.Id() != edges[j].From.Id() {
+			return edges[i].From.Id() < edges[j].From.Id()
+		}
+		return edges[i].To.Id() < edges[j].To.Id()
+	})
+	for _, edge := range edges {
+		from, to := edge.From.Data.Name, edge.To.Data.Name
+		inverse := m.DirectionInverseMapper(edge.Data)
+		if inverse == "" {
+			continue
+		}
+		if back := m.Graph.GetEdge(edge.To.Id(), edge.From.Id()); back != nil {
+			if back.Data != inverse {
+				return fmt.Errorf("%w '%s %s=%s' needs '%s %s=%s' but it goes back %s", ErrorSymmetricConflict,
+					from, edge.Data, to, to, inverse, from, back.Data)
+			}
+			continue
+		}
+		for _, other := range m.Graph.Neighbors(edge.To.Id()) {
+			if other.Data == inverse {
+				return fmt.Errorf("%w '%s %s=%s' needs '%s %s=%s' but '%s' already goes %s to '%s'", ErrorSymmetricConflict,
+					from, edge.Data, to, to, inverse, from, to, inverse, other.To.Data.Name)
+			}
+		}
+		if err := m.AddPath(to, from, inverse); err != nil {
+			return err
+		}
+		m.setRoadAttributes(to, from, m.RoadAttributes(from, to))
+	}
+	return nil
+}
+
+// EdgeToString returns the string representation of an edge, with the road attributes that are not the default ones
 func (m *Map[N, E]) EdgeToString(edge *graph.Edge[City, Direction]) string {
-	return fmt.Sprintf("%s=%s", string(edge.Data), edge.To.Data.Name)
+	attrs := m.RoadAttributes(edge.From.Data.Name, edge.To.Data.Name)
+	return fmt.Sprintf("%s=%s%s", string(edge.Data), edge.To.Data.Name, attrs.ToString())
 }
 
 // ToString returns the map representation as a string
 func (m *Map[N, E]) ToString() string {
+	return m.ToText(false)
+}
+
+// ToText returns the map on the input file format. Cities without roads are skipped unless keepIsolated is set,
+// in which case they are written as a line with only the city name.
+func (m *Map[N, E]) ToText(keepIsolated bool) string {
 	result := ""
 	keys := make([]string, 0, len(m.Cities))
 	for k := range m.Cities {
@@ -100,7 +271,7 @@ func (m *Map[N, E]) ToString() string {
 	for _, cityName := range sortedKeys {
 		city := m.Cities[cityName]
 		paths, _ := m.GetPaths(city)
-		if len(paths) == 0 {
+		if len(paths) == 0 && !keepIsolated {
 			continue
 		}
 		result += city.Name
@@ -120,16 +291,16 @@ func (m *Map[N, E]) ToString() string {
 	return result
 }
 
-// AddPath creates a path on the map between 2 cities on the given direction.
+// AddPath creates a path on the map between 2 cities on the given direction. A city can have several roads on the same
+// direction, as loaded maps always could, LintMap reports them as duplicate-direction.
 func (m *Map[N, E]) AddPath(fromCityName string, toCityName string, dir Direction) error {
-	// TODO ENFORCE ONLY ONE DIRECTION EXISTS PER CITY
 	if _, ok := m.Cities[fromCityName]; !ok {
 		return ErrorCityDoesNotExists
 	}
 	if _, ok := m.Cities[toCityName]; !ok {
 		return ErrorCityDoesNotExists
 	}
-	if !StringInSlice(string(dir), ValidDirections) {
+	if !m.directionSet().Contains(dir) {
 		return ErrorInvalidDirection
 	}
 	// Add edge on graph
@@ -149,7 +320,8 @@ func (m *Map[N, E]) DestroyCity(city *City) error {
 	if id == nil {
 		return ErrorCityDoesNotExists
 	}
-	_ = m.Graph.RemoveVertex(*m.Graph.GetVertexByStringID(city.Name))
+	m.removeRoadAttributes(id)
+	_ = m.Graph.RemoveVertex(*id)
 	// Remove City from Map
 	delete(m.Cities, city.Name)
 	// Destroy City
@@ -157,43 +329,81 @@ func (m *Map[N, E]) DestroyCity(city *City) error {
 	return nil
 }
 
-// GetPaths returns the possible paths from the given city
+// GetPaths returns a copy of the possible paths from the given city
 func (m *Map[N, E]) GetPaths(fromCity *City) (map[graph.VertexID]*graph.Edge[City, Direction], error) {
-	vertex := m.Graph.GetVertexByStringID(fromCity.Name)
-	if vertex == nil {
+	paths := m.Graph.Neighbors(graph.VertexID(fromCity.Name))
+	if paths == nil {
 		return nil, ErrorCityDoesNotExists
 	}
+	return paths, nil
+}
 
-	return vertex.OutgoingEdges, nil
+// graphCity returns the city stored on the graph vertex, which is the one tracking the aliens on it.
+func (m *Map[N, E]) graphCity(cityName string) *City {
+	vertex := m.Graph.GetVertexByStringID(cityName)
+	if vertex == nil {
+		return nil
+	}
+	return &vertex.Data
+}
 
+// setCityAttributes sets the attributes of a city on the map and on the graph.
+func (m *Map[N, E]) setCityAttributes(cityName string, attributes map[string]any) {
+	if city, ok := m.Cities[cityName]; ok {
+		city.Attributes = attributes
+	}
+	if city := m.graphCity(cityName); city != nil {
+		city.Attributes = attributes
+	}
 }
 
-// GetCitiesNames returns the available cities on a map as a string slice
+// GetCitiesNames returns the available cities on a map as a sorted string slice
 func (m *Map[N, E]) GetCitiesNames() []string {
 	keys := []string{}
 	for key, _ := range m.Cities {
 		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
-// validTextRow validates that a string complies with the expected syntax for map parsing
-func validTextRow(rowFields []string) error {
+// ReachableCities returns the sorted names of the cities that can be reached from the city following its roads. The
+// city is only included when a round trip leads back to it.
+func (m *Map[N, E]) ReachableCities(cityName string) ([]string, error) {
+	if _, ok := m.Cities[cityName]; !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrorCityDoesNotExists, cityName)
+	}
+	ids, err := m.Graph.StrictSuccessors(graph.VertexID(cityName))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, string(id))
+	}
+	return names, nil
+}
+
+// validTextRow validates that a string complies with the expected syntax for map parsing and uses the given directions
+func validTextRow(rowFields []string, directions *DirectionSet) error {
 	if len(rowFields) == 0 {
-		return errors.New(fmt.Sprintf("Invalid row: '%v' Needs to have at least a city name", rowFields))
+		return fmt.Errorf("%w Invalid row: '%v' Needs to have at least a city name", ErrorEmptyRow, rowFields)
 	}
 	if len(rowFields) > 1 {
 		for i, field := range rowFields {
 			if i == 0 {
 				continue
 			}
-			splitted := strings.Split(field, "=")
-			if len(splitted) != 2 {
-				return errors.New(fmt.Sprintf("Invalid row: '%v' each path needs to have format 'direction=city'", rowFields))
+			dir, target, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("%w Invalid row: '%v' each path needs to have format 'direction=city'", InvalidToken, rowFields)
 			}
 
-			if !StringInSlice(splitted[0], ValidDirections) {
-				return errors.New(fmt.Sprintf("Invalid row: '%v' direction needs to be one from %v", rowFields, ValidDirections))
+			if !directions.Contains(Direction(dir)) {
+				return fmt.Errorf("%w Invalid row: '%v' direction needs to be one from %v", ErrorInvalidDirection, rowFields, directions.Names())
+			}
+			if _, _, _, err := parseRoadTarget(target); err != nil {
+				return fmt.Errorf("%w Invalid row: '%v'", err, rowFields)
 			}
 
 		}
@@ -203,22 +413,29 @@ func validTextRow(rowFields []string) error {
 
 var InvalidToken = errors.New("Invalid token syntax.")
 
-// buildPathFromToken creates an edge from one city into another one in a given direction from token string.
+// buildPathFromToken creates an edge from one city into another one in a given direction from token string. The
+// token can end with the attributes of the road, like 'north=Bar:3:capacity=2'.
 func (m *Map[N, E]) buildPathFromToken(fromCity *City, elm string) error {
-	splitted := strings.Split(elm, "=")
-	if len(splitted) != 2 {
+	dir, target, ok := strings.Cut(elm, "=")
+	if !ok {
+		return InvalidToken
+	}
+	cityName, attrs, _, err := parseRoadTarget(target)
+	if err != nil {
+		return err
+	}
+	if cityName == "" || strings.Contains(cityName, "=") {
 		return InvalidToken
 	}
-	dir := splitted[0]
-	cityName := splitted[1]
 	if cityName == fromCity.Name {
 		return ErrorPathToSameCity
 	}
 	cityObj := m.getOrCreateCity(cityName)
-	err := m.AddPath(fromCity.Name, cityObj.Name, Direction(dir))
+	err = m.AddPath(fromCity.Name, cityObj.Name, Direction(dir))
 	if err != nil {
 		return err
 	}
+	m.setRoadAttributes(fromCity.Name, cityObj.Name, attrs)
 	return nil
 }
 
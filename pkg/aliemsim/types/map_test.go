@@ -46,7 +46,7 @@ func (s *MapTestSuite) TestValidTextRow() {
 	}
 	for _, val := range testVals {
 		s.Run(val.name, func() {
-			err := validTextRow(val.fields)
+			err := validTextRow(val.fields, DefaultDirections)
 			if val.wantErr {
 				s.NotNil(err)
 			} else {
@@ -373,6 +373,25 @@ func (s *MapTestSuite) TestToString() {
 	}
 }
 
+func (s *MapTestSuite) TestToText() {
+	mapobj := Map[City, Direction]{
+		Cities: CityStore{},
+		Graph:  graph.NewGraph[City, Direction](),
+	}
+	mapobj.getOrCreateCity("city1")
+	mapobj.getOrCreateCity("city2")
+	mapobj.getOrCreateCity("city3")
+	mapobj.AddPath("city1", "city2", "north")
+	mapobj.AddPath("city2", "city1", "south")
+	s.Equal("city1 north=city2\ncity2 south=city1\n", mapobj.ToText(false))
+	s.Equal("city1 north=city2\ncity2 south=city1\ncity3\n", mapobj.ToText(true))
+
+	parsed, err := NewMapFromReader(strings.NewReader(mapobj.ToText(true)))
+	s.Nil(err)
+	s.Equal(mapobj.GetCitiesNames(), parsed.GetCitiesNames())
+	s.Equal(mapobj.ToText(true), parsed.ToText(true))
+}
+
 func (s *MapTestSuite) TestNewMapFromReader() {
 	vals := []struct {
 		name             string
@@ -474,6 +493,117 @@ func (s *MapTestSuite) TestNewMapFromReader() {
 	}
 }
 
+func (s *MapTestSuite) TestNewMapFromReaderSymmetric() {
+	vals := []struct {
+		name string
+		text string
+		err  error
+		want string
+	}{
+		{
+			name: "adds missing roads back",
+			text: "Foo north=Bar west=Baz\nBar south=Foo\n",
+			want: "Bar south=Foo\nBaz east=Foo\nFoo north=Bar west=Baz\n",
+		},
+		{
+			name: "road back declared on a later line",
+			text: "Foo north=Bar\nBar south=Foo east=Qux\n",
+			want: "Bar south=Foo east=Qux\nFoo north=Bar\nQux west=Bar\n",
+		},
+		{
+			name: "road back on another direction",
+			text: "Foo north=Bar\nBar east=Foo\n",
+			err:  ErrorSymmetricConflict,
+		},
+		{
+			name: "inverse direction already taken",
+			text: "Foo north=Bar\nBaz north=Bar\n",
+			err:  ErrorSymmetricConflict,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			m, err := NewMapFromReaderWithOptions(strings.NewReader(val.text), MapOptions{Symmetric: true})
+			if val.err != nil {
+				s.ErrorIs(err, val.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(val.want, m.ToString())
+		})
+	}
+	m, err := NewMapFromReaderWithOptions(strings.NewReader("Foo north=Bar\n"), MapOptions{})
+	s.Nil(err)
+	s.Equal("Foo north=Bar\n", m.ToString())
+}
+
+func (s *MapTestSuite) TestNewMapFromReaderDirections() {
+	updown := mustParseDirectionSet("compass,updown")
+	vals := []struct {
+		name    string
+		text    string
+		options MapOptions
+		err     error
+		want    string
+	}{
+		{
+			name: "header with up and down",
+			text: "#directions: compass,updown\nDeck1 up=Deck2 north=Bay\n",
+			want: "Deck1 north=Bay up=Deck2\n",
+		},
+		{
+			name:    "header after comments",
+			text:    "# station\n\n#directions: updown\nDeck1 up=Deck2\n",
+			options: MapOptions{Comments: true},
+			want:    "Deck1 up=Deck2\n",
+		},
+		{
+			name: "up without a header",
+			text: "Deck1 up=Deck2\n",
+			err:  ErrorInvalidDirection,
+		},
+		{
+			name:    "directions option",
+			text:    "Deck1 up=Deck2\n",
+			options: MapOptions{Directions: updown},
+			want:    "Deck1 up=Deck2\n",
+		},
+		{
+			name:    "directions option overrides the header",
+			text:    "#directions: fore:aft\nDeck1 up=Deck2\n",
+			options: MapOptions{Directions: updown},
+			want:    "Deck1 up=Deck2\n",
+		},
+		{
+			name: "header overrides the compass",
+			text: "#directions: fore:aft\nDeck1 north=Deck2\n",
+			err:  ErrorInvalidDirection,
+		},
+		{
+			name: "invalid header",
+			text: "#directions: up:down,up:left\nDeck1 up=Deck2\n",
+			err:  ErrorInvalidDirectionSet,
+		},
+		{
+			name:    "symmetric uses the inverses of the set",
+			text:    "#directions: updown,hatch\nDeck1 up=Deck2 hatch=Pod\n",
+			options: MapOptions{Symmetric: true},
+			want:    "Deck1 up=Deck2 hatch=Pod\nDeck2 down=Deck1\n",
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			m, err := NewMapFromReaderWithOptions(strings.NewReader(val.text), val.options)
+			if val.err != nil {
+				s.ErrorIs(err, val.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(val.want, m.ToString())
+		})
+	}
+}
+
 func (s *MapTestSuite) TestInverseMapper() {
 	vals := []struct {
 		name   string
@@ -573,3 +703,120 @@ func (s *MapTestSuite) TestDestroyCity() {
 		})
 	}
 }
+
+func (s *MapTestSuite) TestReachableCities() {
+	m, _ := NewMapFromReader(strings.NewReader("a east=b\nb east=c\nc\nd west=a\n"))
+	vals := []struct {
+		city   string
+		cities []string
+	}{
+		{city: "a", cities: []string{"b", "c"}},
+		{city: "d", cities: []string{"a", "b", "c"}},
+		{city: "c", cities: []string{}},
+	}
+	for _, val := range vals {
+		cities, err := m.ReachableCities(val.city)
+		s.Nil(err)
+		s.Equal(val.cities, cities)
+	}
+	_, err := m.ReachableCities("z")
+	s.ErrorIs(err, ErrorCityDoesNotExists)
+
+	s.Nil(m.AddPath("c", "a", "north"))
+	cities, _ := m.ReachableCities("a")
+	s.Equal([]string{"a", "b", "c"}, cities)
+}
+
+func (s *MapTestSuite) TestGetPaths() {
+	m, _ := NewMapFromReader(strings.NewReader("a east=b north=c\nb\nc\n"))
+	a := m.Cities["a"]
+	paths, err := m.GetPaths(a)
+	s.Nil(err)
+	s.Len(paths, 2)
+	s.Contains(paths, graph.VertexID("b"))
+	s.Contains(paths, graph.VertexID("c"))
+
+	// the paths are a copy, changing them does not change the map
+	delete(paths, "b")
+	paths, _ = m.GetPaths(a)
+	s.Len(paths, 2)
+
+	_, err = m.GetPaths(&City{Name: "z"})
+	s.ErrorIs(err, ErrorCityDoesNotExists)
+}
+
+func (s *MapTestSuite) TestClone() {
+	m, err := NewMapFromReader(strings.NewReader("a east=b:3 north=c\nb west=a\nc\n"))
+	s.Nil(err)
+	m.setCityAttributes("a", map[string]any{"population": 10})
+	alien := &Alien{ID: 1, Name: "alien", CurrentCityName: "a", Map: m, Transit: &Transit{From: "b", To: "a"}}
+	m.Cities["a"].Aliens = []*Alien{alien}
+
+	text := m.ToText(true)
+	clone := m.Clone()
+	s.Equal(text, clone.ToText(true))
+	s.Equal(m.Roads, clone.Roads)
+	s.Same(m.Directions, clone.Directions)
+	s.Equal(Direction("south"), clone.DirectionInverseMapper("north"))
+	for name, city := range clone.Cities {
+		s.NotSame(m.Cities[name], city)
+		s.Equal(m.Cities[name].Name, city.Name)
+	}
+	a := clone.Cities["a"]
+	s.Equal(map[string]any{"population": 10}, a.Attributes)
+	s.Equal(a.Attributes, clone.graphCity("a").Attributes)
+	s.Len(a.Aliens, 1)
+	s.NotSame(alien, a.Aliens[0])
+	s.Same(clone, a.Aliens[0].Map)
+	s.NotSame(alien.Transit, a.Aliens[0].Transit)
+	s.Equal(*alien.Transit, *a.Aliens[0].Transit)
+
+	// invading the clone does not change the original
+	a.Attributes["population"] = 0
+	s.Nil(clone.DestroyCity(a))
+	s.NotContains(clone.Cities, "a")
+	s.False(alien.IsDead)
+	s.Equal(10, m.Cities["a"].Attributes["population"])
+	s.Equal(10, m.graphCity("a").Attributes["population"])
+	s.Len(m.Cities, 3)
+	s.Len(m.Roads, 1)
+	s.Empty(clone.Roads)
+	s.Equal(text, m.ToText(true))
+}
+
+// TestSnapshot tests that the snapshot of a map does not change when the invasion changes the cities in place
+func (s *MapTestSuite) TestSnapshot() {
+	m, err := NewMapFromReader(strings.NewReader("a east=b\nb west=a\n"))
+	s.Nil(err)
+	m.setCityAttributes("a", map[string]any{"population": 10})
+	city := m.graphCity("a")
+	for i := 0; i < 3; i++ {
+		city.Aliens = append(city.Aliens, &Alien{ID: i, Name: fmt.Sprintf("alien%d", i), CurrentCityName: "a", Map: m})
+	}
+
+	snapshot := m.Snapshot()
+	done := make(chan []int)
+	go func() {
+		a, _ := snapshot.Vertex("a")
+		ids := []int{}
+		for _, alien := range a.Aliens {
+			ids = append(ids, alien.ID)
+		}
+		done <- ids
+	}()
+	city.removeAlien(city.Aliens[0])
+	city.Aliens[0].IsDead = true
+	city.Attributes["population"] = 0
+	s.Nil(m.DestroyCity(m.Cities["b"]))
+	s.Equal([]int{0, 1, 2}, <-done)
+
+	a, ok := snapshot.Vertex("a")
+	s.True(ok)
+	s.Len(a.Aliens, 3)
+	s.Equal(0, a.Aliens[0].ID)
+	s.False(a.Aliens[1].IsDead)
+	s.Nil(a.Aliens[0].Map)
+	s.Equal(10, a.Attributes["population"])
+	s.Equal([]graph.VertexID{"a", "b"}, snapshot.VertexIDs())
+	s.Equal([]graph.VertexID{"b"}, snapshot.Neighbors("a"))
+}
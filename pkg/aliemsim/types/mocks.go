@@ -1,22 +1,24 @@
 package types
 
 import (
+	"alien-invasion-simulator/pkg/graph"
 	"errors"
 	"io"
 )
 
 var ErrorNewMapMock = errors.New("ErrorNewMapMock")
 
-func NewMapFromReaderMock(reader io.Reader) (*Map[City, Direction], error) {
+func NewMapFromReaderMock(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
 	return &Map[City, Direction]{
 		Cities: CityStore{
 			"test1": &City{Name: "test1"},
 			"test2": &City{Name: "test2"},
 			"test3": &City{Name: "test3"},
 		},
+		Graph: graph.NewGraph[City, Direction](),
 	}, nil
 }
 
-func NewMapFromReaderMockErr(reader io.Reader) (*Map[City, Direction], error) {
+func NewMapFromReaderMockErr(reader io.Reader, options MapOptions) (*Map[City, Direction], error) {
 	return &Map[City, Direction]{}, ErrorNewMapMock
 }
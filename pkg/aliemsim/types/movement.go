@@ -0,0 +1,274 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidMovementStrategy = errors.New("Invalid movement strategy.")
+
+// Road is a road leaving a city.
+type Road = graph.Edge[City, Direction]
+
+// MovementStrategy decides how an alien moves on its turn.
+type MovementStrategy interface {
+	// WillMove decides if the alien tries to move on this turn.
+	WillMove(alien *Alien, rng *rand.Rand) bool
+	// ChooseRoad picks one of the roads leaving the alien city. Roads are sorted by destination and never empty.
+	// Returning nil keeps the alien on its city, which counts as a movement like for trapped aliens.
+	ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road
+}
+
+// RandomWalkStrategy randomly decides to move and takes a random road. It is the default strategy.
+type RandomWalkStrategy struct{}
+
+func (RandomWalkStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return rng.Intn(2) == 1
+}
+
+func (RandomWalkStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	return roads[rng.Intn(len(roads))]
+}
+
+// AlwaysMoveStrategy moves on every turn taking a random road.
+type AlwaysMoveStrategy struct {
+	RandomWalkStrategy
+}
+
+func (AlwaysMoveStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return true
+}
+
+// WeightedRoadStrategy moves on every turn choosing roads with a probability proportional to the weight of their
+// direction. Directions without weight have weight 1.
+type WeightedRoadStrategy struct {
+	Weights map[Direction]float64
+}
+
+func (WeightedRoadStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return true
+}
+
+func (ws WeightedRoadStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	weights := make([]float64, len(roads))
+	for i, road := range roads {
+		weights[i] = 1
+		if w, ok := ws.Weights[road.Data]; ok {
+			weights[i] = w
+		}
+	}
+	return chooseWeighted(roads, weights, rng)
+}
+
+// NearestRoadStrategy moves on every turn choosing roads with a probability inversely proportional to their distance,
+// so close cities are visited more often.
+type NearestRoadStrategy struct{}
+
+func (NearestRoadStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return true
+}
+
+func (NearestRoadStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	weights := make([]float64, len(roads))
+	for i, road := range roads {
+		weights[i] = 1
+		if alien.Map != nil {
+			weights[i] = 1 / float64(alien.Map.RoadDistance(road))
+		}
+	}
+	return chooseWeighted(roads, weights, rng)
+}
+
+// AvoidOccupiedStrategy moves on every turn to a random city without aliens. It stays when every neighbour is occupied.
+type AvoidOccupiedStrategy struct{}
+
+func (AvoidOccupiedStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return true
+}
+
+func (AvoidOccupiedStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	free := []*Road{}
+	for _, road := range roads {
+		if len(road.To.Data.Aliens) == 0 {
+			free = append(free, road)
+		}
+	}
+	if len(free) == 0 {
+		return nil
+	}
+	return free[rng.Intn(len(free))]
+}
+
+// SeekHubStrategy moves on every turn to the neighbour with the most roads leaving it. Ties are broken randomly.
+type SeekHubStrategy struct{}
+
+func (SeekHubStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return true
+}
+
+func (SeekHubStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	best := []*Road{}
+	bestDegree := -1
+	for _, road := range roads {
+		degree := len(alien.Map.Graph.Neighbors(road.To.Id()))
+		if degree > bestDegree {
+			best = []*Road{}
+			bestDegree = degree
+		}
+		if degree == bestDegree {
+			best = append(best, road)
+		}
+	}
+	return best[rng.Intn(len(best))]
+}
+
+// stayPutStrategy never moves.
+type stayPutStrategy struct{}
+
+func (stayPutStrategy) WillMove(alien *Alien, rng *rand.Rand) bool {
+	return false
+}
+
+func (stayPutStrategy) ChooseRoad(alien *Alien, roads []*Road, rng *rand.Rand) *Road {
+	return nil
+}
+
+// StayPut is the strategy of aliens that never move. The simulator counts them as done from the start.
+var StayPut MovementStrategy = stayPutStrategy{}
+
+// chooseWeighted picks a road with probability proportional to its weight. Returns nil when all weights are 0.
+func chooseWeighted(roads []*Road, weights []float64, rng *rand.Rand) *Road {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return nil
+	}
+	target := rng.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return roads[i]
+		}
+		target -= w
+	}
+	return roads[len(roads)-1]
+}
+
+// sortedRoads returns the roads sorted by destination city name.
+func sortedRoads(paths map[graph.VertexID]*Road) []*Road {
+	roads := make([]*Road, 0, len(paths))
+	for _, road := range paths {
+		roads = append(roads, road)
+	}
+	sort.Slice(roads, func(i, j int) bool { return roads[i].To.Id() < roads[j].To.Id() })
+	return roads
+}
+
+var MovementStrategyNames = []string{"random-walk", "always-move", "weighted", "nearest", "avoid-occupied", "seek-hub", "stay-put"}
+
+// ParseMovementStrategy creates a movement strategy from its name. The weighted strategy takes the direction weights
+// as 'weighted:north=2;south=0.5'.
+func ParseMovementStrategy(spec string) (MovementStrategy, error) {
+	name, arg, hasArg := strings.Cut(spec, ":")
+	if hasArg && name != "weighted" {
+		return nil, fmt.Errorf("%w '%s' does not take parameters", ErrorInvalidMovementStrategy, name)
+	}
+	switch name {
+	case "random-walk":
+		return RandomWalkStrategy{}, nil
+	case "always-move":
+		return AlwaysMoveStrategy{}, nil
+	case "weighted":
+		weights := map[Direction]float64{}
+		if hasArg {
+			for _, pair := range strings.Split(arg, ";") {
+				dir, value, ok := strings.Cut(pair, "=")
+				weight, err := strconv.ParseFloat(value, 64)
+				if !ok || err != nil || weight < 0 {
+					return nil, fmt.Errorf("%w Weights need to have format 'direction=weight', got '%s'", ErrorInvalidMovementStrategy, pair)
+				}
+				weights[Direction(dir)] = weight
+			}
+		}
+		return WeightedRoadStrategy{Weights: weights}, nil
+	case "nearest":
+		return NearestRoadStrategy{}, nil
+	case "avoid-occupied":
+		return AvoidOccupiedStrategy{}, nil
+	case "seek-hub":
+		return SeekHubStrategy{}, nil
+	case "stay-put":
+		return StayPut, nil
+	}
+	return nil, fmt.Errorf("%w '%s' needs to be one from %v", ErrorInvalidMovementStrategy, spec, MovementStrategyNames)
+}
+
+// StrategyShare is the percentage of aliens using a strategy.
+type StrategyShare struct {
+	Name     string
+	Strategy MovementStrategy
+	Percent  float64
+}
+
+// StrategyMix is a set of strategies shared by the aliens. Percentages add up to 100.
+type StrategyMix []StrategyShare
+
+// ParseStrategyMix parses a comma separated list of 'strategy@percent' like 'random-walk@70,seek-hub@30'.
+// A single strategy without percentage is used by every alien.
+func ParseStrategyMix(spec string) (StrategyMix, error) {
+	mix := StrategyMix{}
+	total := 0.0
+	for _, item := range strings.Split(spec, ",") {
+		name, percentStr, hasPercent := strings.Cut(item, "@")
+		percent := 100.0
+		if hasPercent {
+			p, err := strconv.ParseFloat(percentStr, 64)
+			if err != nil || p < 0 {
+				return nil, fmt.Errorf("%w Invalid percentage '%s'", ErrorInvalidMovementStrategy, percentStr)
+			}
+			percent = p
+		}
+		strategy, err := ParseMovementStrategy(name)
+		if err != nil {
+			return nil, err
+		}
+		mix = append(mix, StrategyShare{Name: name, Strategy: strategy, Percent: percent})
+		total += percent
+	}
+	if total < 99.999 || total > 100.001 {
+		return nil, fmt.Errorf("%w Percentages of '%s' need to add up to 100", ErrorInvalidMovementStrategy, spec)
+	}
+	return mix, nil
+}
+
+// Assign returns the strategy of each of numAliens aliens. The number of aliens per strategy follows the
+// percentages, and strategies are shuffled among the aliens with rng.
+func (mix StrategyMix) Assign(numAliens int, rng *rand.Rand) []MovementStrategy {
+	result := []MovementStrategy{}
+	remainders := make([]float64, len(mix))
+	for i, share := range mix {
+		exact := share.Percent * float64(numAliens) / 100
+		count := int(exact)
+		remainders[i] = exact - float64(count)
+		for j := 0; j < count; j++ {
+			result = append(result, share.Strategy)
+		}
+	}
+	// hand out the aliens lost by rounding down to the largest remainders
+	order := make([]int, len(mix))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for i := 0; len(result) < numAliens; i++ {
+		result = append(result, mix[order[i%len(order)]].Strategy)
+	}
+	rng.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	return result
+}
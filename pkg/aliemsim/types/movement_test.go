@@ -0,0 +1,157 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"testing"
+)
+
+type MovementTestSuite struct {
+	suite.Suite
+}
+
+func TestMovementTestSuite(t *testing.T) {
+	suite.Run(t, &MovementTestSuite{})
+}
+
+// buildRoadsMap builds a map where "start" has a road to every other city. City "hub" has 2 roads leaving it and
+// city "busy" has an alien.
+func buildRoadsMap() *Map[City, Direction] {
+	m := Map[City, Direction]{
+		Cities: CityStore{},
+		Graph:  graph.NewGraph[City, Direction](),
+	}
+	for _, name := range []string{"start", "busy", "hub", "quiet"} {
+		m.getOrCreateCity(name)
+	}
+	m.AddPath("start", "busy", "north")
+	m.AddPath("start", "hub", "east")
+	m.AddPath("start", "quiet", "south")
+	m.AddPath("hub", "busy", "north")
+	m.AddPath("hub", "quiet", "south")
+	m.graphCity("busy").Aliens = []*Alien{{ID: 9, Name: "resident"}}
+	return &m
+}
+
+func (s *MovementTestSuite) TestChooseRoad() {
+	vals := []struct {
+		name     string
+		strategy MovementStrategy
+		possible []string
+	}{
+		{name: "random walk", strategy: RandomWalkStrategy{}, possible: []string{"busy", "hub", "quiet"}},
+		{name: "always move", strategy: AlwaysMoveStrategy{}, possible: []string{"busy", "hub", "quiet"}},
+		{name: "weighted only east", strategy: WeightedRoadStrategy{Weights: map[Direction]float64{"north": 0, "south": 0}}, possible: []string{"hub"}},
+		{name: "avoid occupied", strategy: AvoidOccupiedStrategy{}, possible: []string{"hub", "quiet"}},
+		{name: "seek hub", strategy: SeekHubStrategy{}, possible: []string{"hub"}},
+	}
+	m := buildRoadsMap()
+	paths, _ := m.GetPaths(m.Cities["start"])
+	roads := sortedRoads(paths)
+	alien := NewAlien(0, "alien", "start", m)
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			rng := rand.New(rand.NewSource(3))
+			for i := 0; i < 20; i++ {
+				road := val.strategy.ChooseRoad(&alien, roads, rng)
+				s.NotNil(road)
+				s.Contains(val.possible, road.To.Data.Name)
+			}
+		})
+	}
+}
+
+func (s *MovementTestSuite) TestChooseRoadStays() {
+	m := buildRoadsMap()
+	paths, _ := m.GetPaths(m.Cities["start"])
+	roads := sortedRoads(paths)[:1]
+	alien := NewAlien(0, "alien", "start", m)
+	rng := rand.New(rand.NewSource(3))
+	s.Equal("busy", roads[0].To.Data.Name)
+	s.Nil(AvoidOccupiedStrategy{}.ChooseRoad(&alien, roads, rng))
+	s.Nil(WeightedRoadStrategy{Weights: map[Direction]float64{"north": 0}}.ChooseRoad(&alien, roads, rng))
+	s.Nil(StayPut.ChooseRoad(&alien, roads, rng))
+	s.False(StayPut.WillMove(&alien, rng))
+	s.True(AlwaysMoveStrategy{}.WillMove(&alien, rng))
+}
+
+func (s *MovementTestSuite) TestParseMovementStrategy() {
+	vals := []struct {
+		spec     string
+		strategy MovementStrategy
+		withErr  bool
+	}{
+		{spec: "random-walk", strategy: RandomWalkStrategy{}},
+		{spec: "always-move", strategy: AlwaysMoveStrategy{}},
+		{spec: "weighted", strategy: WeightedRoadStrategy{Weights: map[Direction]float64{}}},
+		{spec: "weighted:north=2;south=0.5", strategy: WeightedRoadStrategy{Weights: map[Direction]float64{"north": 2, "south": 0.5}}},
+		{spec: "nearest", strategy: NearestRoadStrategy{}},
+		{spec: "avoid-occupied", strategy: AvoidOccupiedStrategy{}},
+		{spec: "seek-hub", strategy: SeekHubStrategy{}},
+		{spec: "stay-put", strategy: StayPut},
+		{spec: "weighted:north", withErr: true},
+		{spec: "weighted:north=-1", withErr: true},
+		{spec: "seek-hub:1", withErr: true},
+		{spec: "teleport", withErr: true},
+	}
+	for _, val := range vals {
+		s.Run(val.spec, func() {
+			strategy, err := ParseMovementStrategy(val.spec)
+			if val.withErr {
+				s.ErrorIs(err, ErrorInvalidMovementStrategy)
+			} else {
+				s.Nil(err)
+				s.Equal(val.strategy, strategy)
+			}
+		})
+	}
+}
+
+func (s *MovementTestSuite) TestStrategyMix() {
+	vals := []struct {
+		spec      string
+		numAliens int
+		counts    map[MovementStrategy]int
+		withErr   bool
+	}{
+		{spec: "seek-hub", numAliens: 4, counts: map[MovementStrategy]int{SeekHubStrategy{}: 4}},
+		{spec: "seek-hub@50,stay-put@50", numAliens: 4, counts: map[MovementStrategy]int{SeekHubStrategy{}: 2, StayPut: 2}},
+		{spec: "seek-hub@70,stay-put@30", numAliens: 5, counts: map[MovementStrategy]int{SeekHubStrategy{}: 4, StayPut: 1}},
+		{spec: "random-walk@33.4,always-move@33.3,stay-put@33.3", numAliens: 10, counts: map[MovementStrategy]int{RandomWalkStrategy{}: 4, AlwaysMoveStrategy{}: 3, StayPut: 3}},
+		{spec: "seek-hub@50,stay-put@40", withErr: true},
+		{spec: "seek-hub@half", withErr: true},
+		{spec: "seek-hub@50,jump@50", withErr: true},
+	}
+	for _, val := range vals {
+		s.Run(val.spec, func() {
+			mix, err := ParseStrategyMix(val.spec)
+			if val.withErr {
+				s.ErrorIs(err, ErrorInvalidMovementStrategy)
+				return
+			}
+			s.Nil(err)
+			counts := map[MovementStrategy]int{}
+			for _, strategy := range mix.Assign(val.numAliens, rand.New(rand.NewSource(1))) {
+				counts[strategy] += 1
+			}
+			s.Equal(val.counts, counts)
+		})
+	}
+}
+
+func (s *MovementTestSuite) TestSimulateStayPut() {
+	m := buildRoadsMap()
+	stayer := NewAlien(0, "stayer", "start", m)
+	stayer.Strategy = StayPut
+	mover := NewAlien(1, "mover", "hub", m)
+	sim := NewAlienSimulator(m, []*Alien{&stayer, &mover}, 10, false)
+	sim.Sink = nil
+	sim.Movement = AvoidOccupiedStrategy{}
+	s.Nil(sim.SimulateInvasion())
+	s.Equal("start", stayer.CurrentCityName)
+	s.Equal(0, stayer.NumMovements)
+	s.False(stayer.CanMove)
+	s.Equal("quiet", mover.CurrentCityName)
+	s.Equal(StopAllAliensDone, sim.StopReason)
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultMaxLineLength is the longest line accepted on a map file when MapOptions.MaxLineLength is 0.
+const DefaultMaxLineLength = 1 << 20
+
+var ErrorLineTooLong = errors.New("Line too long.")
+var ErrorEmptyRow = errors.New("Empty row.")
+
+// ParseError is a problem found on a line of a map file. Use errors.As to get its position and errors.Is to check
+// the cause.
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// maxLineLength returns the longest line accepted by the options.
+func (o MapOptions) maxLineLength() int {
+	if o.MaxLineLength > 0 {
+		return o.MaxLineLength
+	}
+	return DefaultMaxLineLength
+}
+
+// handle stops the parsing on strict mode by returning the error. On lenient mode the error goes to OnError and
+// the parsing continues.
+func (o MapOptions) handle(err *ParseError) error {
+	if !o.Lenient {
+		return err
+	}
+	if o.OnError != nil {
+		o.OnError(err)
+	}
+	return nil
+}
+
+// stripComment removes everything after a '#' when comments are enabled.
+func (o MapOptions) stripComment(line string) string {
+	if !o.Comments {
+		return line
+	}
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// lineReader streams the lines of a reader without holding more than maxLength bytes of a line in memory.
+type lineReader struct {
+	reader    *bufio.Reader
+	maxLength int
+	line      int
+}
+
+func newLineReader(reader io.Reader, maxLength int) *lineReader {
+	return &lineReader{reader: bufio.NewReader(reader), maxLength: maxLength}
+}
+
+// next returns the next line without its line ending. Lines longer than maxLength are skipped and returned as a
+// ParseError with ErrorLineTooLong, so reading can go on with the next line. Returns io.EOF after the last line.
+func (lr *lineReader) next() (string, error) {
+	var buf []byte
+	tooLong := false
+	for {
+		chunk, isPrefix, err := lr.reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if !tooLong {
+			buf = append(buf, chunk...)
+			if len(buf) > lr.maxLength {
+				tooLong = true
+				buf = nil
+			}
+		}
+		if !isPrefix {
+			break
+		}
+	}
+	lr.line++
+	if tooLong {
+		return "", &ParseError{Line: lr.line, Column: lr.maxLength + 1, Err: fmt.Errorf("%w Lines can have up to %d bytes", ErrorLineTooLong, lr.maxLength)}
+	}
+	return string(buf), nil
+}
+
+// lineToken is a whitespace separated field of a map line with the column where it starts.
+type lineToken struct {
+	text   string
+	column int
+}
+
+// splitTokens splits a line like strings.Fields keeping the column of every field.
+func splitTokens(line string) []lineToken {
+	tokens := []lineToken{}
+	start := -1
+	for i, r := range line + " " {
+		isSpace := r == ' ' || r == '\t' || r == '\r'
+		if isSpace && start >= 0 {
+			tokens = append(tokens, lineToken{text: line[start:i], column: start + 1})
+			start = -1
+		} else if !isSpace && start < 0 {
+			start = i
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"strings"
+	"testing"
+)
+
+type ParseTestSuite struct {
+	suite.Suite
+}
+
+func TestParseTestSuite(t *testing.T) {
+	suite.Run(t, &ParseTestSuite{})
+}
+
+func (s *ParseTestSuite) TestSplitTokens() {
+	s.Equal([]lineToken{}, splitTokens("   "))
+	s.Equal([]lineToken{{text: "Foo", column: 1}, {text: "north=Bar", column: 6}}, splitTokens("Foo \tnorth=Bar  "))
+}
+
+func (s *ParseTestSuite) TestLineReader() {
+	lines := newLineReader(strings.NewReader("short\r\n"+strings.Repeat("x", 40)+"\nlast"), 10)
+	line, err := lines.next()
+	s.Nil(err)
+	s.Equal("short", line)
+	_, err = lines.next()
+	var parseErr *ParseError
+	s.True(errors.As(err, &parseErr))
+	s.Equal(2, parseErr.Line)
+	s.ErrorIs(err, ErrorLineTooLong)
+	line, err = lines.next()
+	s.Nil(err)
+	s.Equal("last", line)
+	s.Equal(3, lines.line)
+	_, err = lines.next()
+	s.Equal(io.EOF, err)
+}
+
+func (s *ParseTestSuite) TestParseErrors() {
+	vals := []struct {
+		name    string
+		text    string
+		options MapOptions
+		err     error
+		line    int
+		column  int
+	}{
+		{name: "invalid direction", text: "Foo north=Bar\nBar  up=Foo\n", err: ErrorInvalidDirection, line: 2, column: 6},
+		{name: "invalid token", text: "Foo north=Bar\nBar south\n", err: InvalidToken, line: 2, column: 5},
+		{name: "road to the same city", text: "Foo north=Foo\n", err: ErrorPathToSameCity, line: 1, column: 5},
+		{name: "blank line", text: "Foo north=Bar\n\nBar south=Foo\n", err: ErrorEmptyRow, line: 2, column: 1},
+		{name: "line too long", text: "Foo north=Bar\nBar south=Foo\n", options: MapOptions{MaxLineLength: 10}, err: ErrorLineTooLong, line: 1, column: 11},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			_, err := NewMapFromReaderWithOptions(strings.NewReader(val.text), val.options)
+			s.ErrorIs(err, val.err)
+			var parseErr *ParseError
+			s.True(errors.As(err, &parseErr))
+			s.Equal(val.line, parseErr.Line)
+			s.Equal(val.column, parseErr.Column)
+		})
+	}
+}
+
+func (s *ParseTestSuite) TestComments() {
+	text := "# generated world\n\nFoo north=Bar   # main road\n  \nBar south=Foo\n"
+	m, err := NewMapFromReaderWithOptions(strings.NewReader(text), MapOptions{Comments: true})
+	s.Nil(err)
+	s.Equal("Bar south=Foo\nFoo north=Bar\n", m.ToString())
+	_, err = NewMapFromReaderWithOptions(strings.NewReader(text), MapOptions{})
+	s.NotNil(err)
+}
+
+func (s *ParseTestSuite) TestLenient() {
+	text := "Foo north=Bar up=Baz west\n" + strings.Repeat("Qux ", 10) + "\nBar south=Foo\n\n"
+	skipped := []*ParseError{}
+	m, err := NewMapFromReaderWithOptions(strings.NewReader(text), MapOptions{
+		Lenient:       true,
+		MaxLineLength: 30,
+		OnError:       func(err *ParseError) { skipped = append(skipped, err) },
+	})
+	s.Nil(err)
+	s.Equal("Bar south=Foo\nFoo north=Bar\n", m.ToString())
+	s.Equal([]string{"Bar", "Foo"}, m.GetCitiesNames())
+	s.Equal(4, len(skipped))
+	s.ErrorIs(skipped[0], ErrorInvalidDirection)
+	s.ErrorIs(skipped[1], InvalidToken)
+	s.ErrorIs(skipped[2], ErrorLineTooLong)
+	s.ErrorIs(skipped[3], ErrorEmptyRow)
+	s.Equal(4, skipped[3].Line)
+}
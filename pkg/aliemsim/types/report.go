@@ -0,0 +1,167 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+	"strings"
+)
+
+var ReportFormats = []string{"json", "yaml", "text"}
+var ErrorInvalidReportFormat = errors.New("Invalid report format.")
+
+// Report summarizes the world after an invasion.
+type Report struct {
+	StopReason      StopReason      `json:"stop_reason" yaml:"stop_reason"`
+	Iterations      int             `json:"iterations" yaml:"iterations"`
+	Seed            int64           `json:"seed" yaml:"seed"`
+	SurvivingCities []CityReport    `json:"surviving_cities" yaml:"surviving_cities"`
+	DestroyedCities []DestroyedCity `json:"destroyed_cities" yaml:"destroyed_cities"`
+	DestroyedRoads  []DestroyedRoad `json:"destroyed_roads,omitempty" yaml:"destroyed_roads,omitempty"`
+	Aliens          []AlienReport   `json:"aliens" yaml:"aliens"`
+}
+
+// CityReport is a surviving city with the roads that are left.
+type CityReport struct {
+	Name  string       `json:"name" yaml:"name"`
+	Roads []RoadReport `json:"roads" yaml:"roads"`
+}
+
+// RoadReport is a road leaving a city.
+type RoadReport struct {
+	Direction Direction `json:"direction" yaml:"direction"`
+	To        string    `json:"to" yaml:"to"`
+	// Distance is only set on roads longer than 1.
+	Distance int `json:"distance,omitempty" yaml:"distance,omitempty"`
+}
+
+// DestroyedCity records when a city was destroyed and the aliens that destroyed it.
+type DestroyedCity struct {
+	Name      string   `json:"name" yaml:"name"`
+	Iteration int      `json:"iteration" yaml:"iteration"`
+	Aliens    []string `json:"aliens" yaml:"aliens"`
+}
+
+// DestroyedRoad records when a road collapsed and why.
+type DestroyedRoad struct {
+	From      string    `json:"from" yaml:"from"`
+	To        string    `json:"to" yaml:"to"`
+	Direction Direction `json:"direction" yaml:"direction"`
+	Iteration int       `json:"iteration" yaml:"iteration"`
+	Cause     string    `json:"cause" yaml:"cause"`
+}
+
+// AlienReport is the final state of an alien.
+type AlienReport struct {
+	ID           int    `json:"id" yaml:"id"`
+	Name         string `json:"name" yaml:"name"`
+	City         string `json:"city" yaml:"city"`
+	Dead         bool   `json:"dead" yaml:"dead"`
+	NumMovements int    `json:"num_movements" yaml:"num_movements"`
+	CanMove      bool   `json:"can_move" yaml:"can_move"`
+	// OnRoad is the trip of an alien still travelling on a road. City is the one it left.
+	OnRoad *Transit `json:"on_road,omitempty" yaml:"on_road,omitempty"`
+}
+
+// Report builds the report of the current state of the simulation.
+func (sim *AlienSimulator) Report() Report {
+	report := Report{
+		StopReason:      sim.StopReason,
+		Iterations:      sim.CurrentIteration,
+		Seed:            sim.Seed,
+		SurvivingCities: []CityReport{},
+		DestroyedCities: append([]DestroyedCity{}, sim.DestroyedCities...),
+		DestroyedRoads:  append([]DestroyedRoad{}, sim.DestroyedRoads...),
+		Aliens:          []AlienReport{},
+	}
+	for _, cityName := range sim.Map.GetCitiesNames() {
+		cityReport := CityReport{Name: cityName, Roads: []RoadReport{}}
+		paths, _ := sim.Map.GetPaths(sim.Map.Cities[cityName])
+		for _, edge := range paths {
+			road := RoadReport{Direction: edge.Data, To: edge.To.Data.Name}
+			if distance := sim.Map.RoadDistance(edge); distance != 1 {
+				road.Distance = distance
+			}
+			cityReport.Roads = append(cityReport.Roads, road)
+		}
+		sort.Slice(cityReport.Roads, func(i, j int) bool { return cityReport.Roads[i].To < cityReport.Roads[j].To })
+		report.SurvivingCities = append(report.SurvivingCities, cityReport)
+	}
+	for _, alien := range sim.Aliens {
+		report.Aliens = append(report.Aliens, AlienReport{
+			ID:           alien.ID,
+			Name:         alien.Name,
+			City:         alien.CurrentCityName,
+			Dead:         alien.IsDead,
+			NumMovements: alien.NumMovements,
+			CanMove:      alien.CanMove,
+			OnRoad:       alien.Transit,
+		})
+	}
+	return report
+}
+
+// Write writes the report into w on the given format. Format must be one of ReportFormats.
+func (r Report) Write(w io.Writer, format string) error {
+	return writeFormatted(w, format, r, r.ToString)
+}
+
+// writeFormatted encodes value into w as json or yaml, or writes its text representation.
+func writeFormatted(w io.Writer, format string, value any, text func() string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "text":
+		_, err := io.WriteString(w, text())
+		return err
+	}
+	return ErrorInvalidReportFormat
+}
+
+// ToString returns the human readable representation of the report.
+func (r Report) ToString() string {
+	result := fmt.Sprintf("Stop reason: %s\nIterations: %d\nSeed: %d\n", r.StopReason, r.Iterations, r.Seed)
+	result += "Surviving cities:\n"
+	for _, city := range r.SurvivingCities {
+		result += fmt.Sprintf("  %s", city.Name)
+		for _, road := range city.Roads {
+			result += fmt.Sprintf(" %s=%s", road.Direction, road.To)
+			if road.Distance != 0 {
+				result += fmt.Sprintf(":%d", road.Distance)
+			}
+		}
+		result += "\n"
+	}
+	result += "Destroyed cities:\n"
+	for _, city := range r.DestroyedCities {
+		result += fmt.Sprintf("  %s on iteration %d by %s\n", city.Name, city.Iteration, strings.Join(city.Aliens, " and "))
+	}
+	if len(r.DestroyedRoads) > 0 {
+		result += "Destroyed roads:\n"
+		for _, road := range r.DestroyedRoads {
+			result += fmt.Sprintf("  %s from %s to %s on iteration %d (%s)\n", road.Direction, road.From, road.To, road.Iteration, road.Cause)
+		}
+	}
+	result += "Aliens:\n"
+	for _, alien := range r.Aliens {
+		result += fmt.Sprintf("  Alien[%d] - Name: %s - City: %s - Dead: %v - NumMovements: %d - Can Move: %v",
+			alien.ID, alien.Name, alien.City, alien.Dead, alien.NumMovements, alien.CanMove)
+		if alien.OnRoad != nil {
+			result += " - On Road: " + alien.OnRoad.ToString()
+		}
+		result += "\n"
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, &ReportTestSuite{})
+}
+
+// buildFightSim builds a simulator where both aliens can only go to city c.
+func buildFightSim() AlienSimulator {
+	m := Map[City, Direction]{
+		Cities: CityStore{},
+		Graph:  graph.NewGraph[City, Direction](),
+	}
+	m.getOrCreateCity("a")
+	m.getOrCreateCity("b")
+	m.getOrCreateCity("c")
+	m.AddPath("a", "c", "north")
+	m.AddPath("b", "c", "south")
+	m.AddPath("a", "b", "east")
+	alien1 := NewAlien(0, "alien1", "a", &m)
+	alien2 := NewAlien(1, "alien2", "b", &m)
+	sim := NewAlienSimulator(&m, []*Alien{&alien1, &alien2}, 100, false)
+	sim.Sink = nil
+	return sim
+}
+
+func (s *ReportTestSuite) TestReport() {
+	sim := buildFightSim()
+	sim.Aliens = sim.Aliens[1:]
+	s.Nil(sim.SimulateInvasion())
+	report := sim.Report()
+	s.Equal(StopAllAliensMaxMoves, report.StopReason)
+	s.Equal(sim.CurrentIteration, report.Iterations)
+	s.Equal([]CityReport{
+		{Name: "a", Roads: []RoadReport{{Direction: "east", To: "b"}, {Direction: "north", To: "c"}}},
+		{Name: "b", Roads: []RoadReport{{Direction: "south", To: "c"}}},
+		{Name: "c", Roads: []RoadReport{}},
+	}, report.SurvivingCities)
+	s.Equal(0, len(report.DestroyedCities))
+	s.Equal([]AlienReport{{ID: 1, Name: "alien2", City: "c", Dead: false, NumMovements: 100, CanMove: false}}, report.Aliens)
+}
+
+func (s *ReportTestSuite) TestReportDestroyedCities() {
+	sim := buildFightSim()
+	sim.Map.Graph.RemoveEdge("a", "b")
+	s.Nil(sim.SimulateInvasion())
+	report := sim.Report()
+	s.Equal(StopAllAliensDead, report.StopReason)
+	s.Equal(1, len(report.DestroyedCities))
+	s.Equal("c", report.DestroyedCities[0].Name)
+	s.ElementsMatch([]string{"alien1", "alien2"}, report.DestroyedCities[0].Aliens)
+	s.Equal(2, len(report.SurvivingCities))
+	for _, alien := range report.Aliens {
+		s.True(alien.Dead)
+	}
+}
+
+func (s *ReportTestSuite) TestWrite() {
+	report := Report{
+		StopReason:      StopAllAliensDead,
+		Iterations:      4,
+		Seed:            9,
+		SurvivingCities: []CityReport{{Name: "a", Roads: []RoadReport{{Direction: "north", To: "b"}}}, {Name: "b", Roads: []RoadReport{}}},
+		DestroyedCities: []DestroyedCity{{Name: "c", Iteration: 3, Aliens: []string{"alien1", "alien2"}}},
+		Aliens:          []AlienReport{{ID: 0, Name: "alien1", City: "c", Dead: true, NumMovements: 2}},
+	}
+	vals := []struct {
+		name    string
+		format  string
+		decode  func([]byte) (Report, error)
+		text    string
+		withErr bool
+	}{
+		{
+			name:   "json report",
+			format: "json",
+			decode: func(data []byte) (Report, error) {
+				r := Report{}
+				err := json.Unmarshal(data, &r)
+				return r, err
+			},
+		},
+		{
+			name:   "yaml report",
+			format: "yaml",
+			decode: func(data []byte) (Report, error) {
+				r := Report{}
+				err := yaml.Unmarshal(data, &r)
+				return r, err
+			},
+		},
+		{
+			name:   "text report",
+			format: "text",
+			text: "Stop reason: all_aliens_dead\nIterations: 4\nSeed: 9\n" +
+				"Surviving cities:\n  a north=b\n  b\n" +
+				"Destroyed cities:\n  c on iteration 3 by alien1 and alien2\n" +
+				"Aliens:\n  Alien[0] - Name: alien1 - City: c - Dead: true - NumMovements: 2 - Can Move: false\n",
+		},
+		{
+			name:    "invalid format",
+			format:  "xml",
+			withErr: true,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			buf := &bytes.Buffer{}
+			err := report.Write(buf, val.format)
+			if val.withErr {
+				s.NotNil(err)
+				s.EqualError(err, ErrorInvalidReportFormat.Error())
+				return
+			}
+			s.Nil(err)
+			if val.decode != nil {
+				decoded, err := val.decode(buf.Bytes())
+				s.Nil(err)
+				s.Equal(report, decoded)
+			} else {
+				s.Equal(val.text, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidDistance = errors.New("Invalid road distance.")
+var ErrorInvalidRoadAttribute = errors.New("Invalid road attribute.")
+var ErrorRoadDoesNotExists = errors.New("Road does not exists.")
+
+// RoadAttributeNames are the attributes a road can have after its city on the text format, like
+// 'north=Bar:3:capacity=2:durability=10:failure=0.05'.
+var RoadAttributeNames = []string{"distance", "capacity", "durability", "failure"}
+
+// RoadAttributes are the attributes of a road that do not fit on the graph edge, which only holds the direction.
+type RoadAttributes struct {
+	// Distance is the number of iterations it takes to travel the road. Roads without attributes have distance 1,
+	// which is an instant arrival.
+	Distance int
+	// Capacity is the number of aliens that can be on the road at once, counting the ones entering it on the same
+	// iteration. 0 is unlimited.
+	Capacity int
+	// Durability is the number of traversals the road stands before it collapses. It goes down while the road is
+	// used. 0 is unlimited.
+	Durability int
+	// Failure is the probability of the road collapsing after each traversal.
+	Failure float64
+}
+
+// defaultRoadAttributes are the attributes of roads that do not have any.
+var defaultRoadAttributes = RoadAttributes{Distance: 1}
+
+// parseRoadTarget splits the 'city:attributes' part of a road token. Attributes are separated by ':' as
+// 'name=value', and the first one can be a bare distance, like 'Bar:3:capacity=2'. The city is returned even when
+// the attributes are invalid, with the offset on target of the attribute that failed. City names cannot contain ':'.
+func parseRoadTarget(target string) (string, RoadAttributes, int, error) {
+	parts := strings.Split(target, ":")
+	attrs := defaultRoadAttributes
+	offset := len(parts[0]) + 1
+	for i, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		bareDistance := !ok && i == 0
+		if bareDistance {
+			name, value = "distance", part
+		}
+		if err := attrs.set(name, value); err != nil {
+			if bareDistance {
+				err = fmt.Errorf("%w, city names cannot contain ':'", err)
+			}
+			return parts[0], RoadAttributes{}, offset, err
+		}
+		offset += len(part) + 1
+	}
+	return parts[0], attrs, 0, nil
+}
+
+// set parses the value of the named attribute.
+func (ra *RoadAttributes) set(name, value string) error {
+	switch name {
+	case "distance":
+		distance, err := strconv.Atoi(value)
+		if err != nil || distance < 1 {
+			return fmt.Errorf("%w '%s' needs to be a positive number of iterations", ErrorInvalidDistance, value)
+		}
+		ra.Distance = distance
+	case "capacity", "durability":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w %s '%s' needs to be a number of aliens or traversals, 0 is unlimited", ErrorInvalidRoadAttribute, name, value)
+		}
+		if name == "capacity" {
+			ra.Capacity = n
+		} else {
+			ra.Durability = n
+		}
+	case "failure":
+		p, err := strconv.ParseFloat(value, 64)
+		if err != nil || p < 0 || p > 1 {
+			return fmt.Errorf("%w failure '%s' needs to be a probability between 0 and 1", ErrorInvalidRoadAttribute, value)
+		}
+		ra.Failure = p
+	default:
+		return fmt.Errorf("%w '%s' needs to be one from %v", ErrorInvalidRoadAttribute, name, RoadAttributeNames)
+	}
+	return nil
+}
+
+// validate checks the attributes loaded from a structured map format, where a 0 distance means 1.
+func (ra RoadAttributes) validate() error {
+	if ra.Distance < 0 {
+		return fmt.Errorf("%w %d needs to be a positive number of iterations", ErrorInvalidDistance, ra.Distance)
+	}
+	if ra.Capacity < 0 || ra.Durability < 0 {
+		return fmt.Errorf("%w capacity and durability cannot be negative", ErrorInvalidRoadAttribute)
+	}
+	if ra.Failure < 0 || ra.Failure > 1 {
+		return fmt.Errorf("%w failure %v needs to be a probability between 0 and 1", ErrorInvalidRoadAttribute, ra.Failure)
+	}
+	return nil
+}
+
+// ToString returns the attributes as the suffix of a road on the text format. Default values are skipped.
+func (ra RoadAttributes) ToString() string {
+	result := ""
+	if ra.Distance > 1 {
+		result += fmt.Sprintf(":%d", ra.Distance)
+	}
+	if ra.Capacity > 0 {
+		result += fmt.Sprintf(":capacity=%d", ra.Capacity)
+	}
+	if ra.Durability > 0 {
+		result += fmt.Sprintf(":durability=%d", ra.Durability)
+	}
+	if ra.Failure > 0 {
+		result += ":failure=" + strconv.FormatFloat(ra.Failure, 'g', -1, 64)
+	}
+	return result
+}
+
+// RoadAttributes returns the attributes of the road between 2 cities. Roads without attributes have distance 1.
+func (m *Map[N, E]) RoadAttributes(fromCityName, toCityName string) RoadAttributes {
+	attrs, ok := m.Roads[graph.EdgeId{From: graph.VertexID(fromCityName), To: graph.VertexID(toCityName)}]
+	if !ok {
+		return defaultRoadAttributes
+	}
+	return attrs
+}
+
+// RoadDistance returns the number of iterations it takes to travel the road.
+func (m *Map[N, E]) RoadDistance(road *Road) int {
+	return m.RoadAttributes(road.From.Data.Name, road.To.Data.Name).Distance
+}
+
+// ShortestRoute returns the cities on the route with the least total distance between 2 cities, both included, and
+// its distance in iterations. Fails with graph.ErrorNoPath when the roads do not lead there.
+func (m *Map[N, E]) ShortestRoute(fromCityName, toCityName string) ([]string, int, error) {
+	for _, name := range []string{fromCityName, toCityName} {
+		if _, ok := m.Cities[name]; !ok {
+			return nil, 0, fmt.Errorf("%w '%s'", ErrorCityDoesNotExists, name)
+		}
+	}
+	ids, distance, err := m.Graph.Dijkstra(graph.VertexID(fromCityName), graph.VertexID(toCityName), func(road *Road) float64 {
+		return float64(m.RoadDistance(road))
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	route := make([]string, 0, len(ids))
+	for _, id := range ids {
+		route = append(route, string(id))
+	}
+	return route, int(distance), nil
+}
+
+// setRoadAttributes stores the attributes of the road between 2 cities. Roads with the default attributes are not
+// stored.
+func (m *Map[N, E]) setRoadAttributes(fromCityName, toCityName string, attrs RoadAttributes) {
+	id := graph.EdgeId{From: graph.VertexID(fromCityName), To: graph.VertexID(toCityName)}
+	if attrs.Distance == 0 {
+		attrs.Distance = 1
+	}
+	if attrs == defaultRoadAttributes {
+		delete(m.Roads, id)
+		return
+	}
+	if m.Roads == nil {
+		m.Roads = map[graph.EdgeId]RoadAttributes{}
+	}
+	m.Roads[id] = attrs
+}
+
+// removeRoadAttributes forgets the attributes of every road of a vertex that is being removed.
+func (m *Map[N, E]) removeRoadAttributes(vertex *graph.Vertex[City, Direction]) {
+	for _, edge := range m.Graph.Neighbors(vertex.Id()) {
+		delete(m.Roads, edge.Id())
+	}
+	for _, edge := range m.Graph.Incoming(vertex.Id()) {
+		delete(m.Roads, edge.Id())
+	}
+}
+
+// DestroyRoad removes the road between 2 cities from the map. Both cities stay, and the road is gone from GetPaths.
+func (m *Map[N, E]) DestroyRoad(fromCityName, toCityName string) error {
+	edge := m.Graph.GetEdge(graph.VertexID(fromCityName), graph.VertexID(toCityName))
+	if edge == nil {
+		return fmt.Errorf("%w '%s' to '%s'", ErrorRoadDoesNotExists, fromCityName, toCityName)
+	}
+	delete(m.Roads, edge.Id())
+	return m.Graph.RemoveEdge(edge.From.Id(), edge.To.Id())
+}
+
+// wearRoad records a traversal of the road and returns true when the road has no durability left.
+func (m *Map[N, E]) wearRoad(fromCityName, toCityName string) bool {
+	attrs := m.RoadAttributes(fromCityName, toCityName)
+	if attrs.Durability == 0 {
+		return false
+	}
+	attrs.Durability--
+	if attrs.Durability == 0 {
+		return true
+	}
+	m.setRoadAttributes(fromCityName, toCityName, attrs)
+	return false
+}
@@ -0,0 +1,356 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type RoadTestSuite struct {
+	suite.Suite
+}
+
+func TestRoadTestSuite(t *testing.T) {
+	suite.Run(t, &RoadTestSuite{})
+}
+
+func (s *RoadTestSuite) TestParseRoadTarget() {
+	vals := []struct {
+		target string
+		city   string
+		attrs  RoadAttributes
+		err    error
+	}{
+		{target: "Bar", city: "Bar", attrs: RoadAttributes{Distance: 1}},
+		{target: "Bar:3", city: "Bar", attrs: RoadAttributes{Distance: 3}},
+		{target: "Bar:1", city: "Bar", attrs: RoadAttributes{Distance: 1}},
+		{target: "Bar:distance=2:capacity=3", city: "Bar", attrs: RoadAttributes{Distance: 2, Capacity: 3}},
+		{target: "Bar:4:durability=10:failure=0.25", city: "Bar", attrs: RoadAttributes{Distance: 4, Durability: 10, Failure: 0.25}},
+		{target: "Bar:capacity=1", city: "Bar", attrs: RoadAttributes{Distance: 1, Capacity: 1}},
+		{target: "Bar:0", err: ErrorInvalidDistance},
+		{target: "Bar:-2", err: ErrorInvalidDistance},
+		{target: "Bar:far", err: ErrorInvalidDistance},
+		{target: "Bar:", err: ErrorInvalidDistance},
+		{target: "Bar:capacity=3:2", err: ErrorInvalidRoadAttribute},
+		{target: "Bar:capacity=-1", err: ErrorInvalidRoadAttribute},
+		{target: "Bar:failure=1.5", err: ErrorInvalidRoadAttribute},
+		{target: "Bar:speed=3", err: ErrorInvalidRoadAttribute},
+	}
+	for _, val := range vals {
+		s.Run(val.target, func() {
+			city, attrs, _, err := parseRoadTarget(val.target)
+			if val.err != nil {
+				s.ErrorIs(err, val.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(val.city, city)
+			s.Equal(val.attrs, attrs)
+			_, again, _, err := parseRoadTarget(city + attrs.ToString())
+			s.Nil(err)
+			s.Equal(attrs, again)
+		})
+	}
+
+	// a ':' in the city name reads as a bad distance
+	_, err := NewMapFromReader(strings.NewReader("Foo north=Bar:East\n"))
+	s.ErrorIs(err, ErrorInvalidDistance)
+	s.ErrorContains(err, "city names cannot contain ':'")
+}
+
+func (s *RoadTestSuite) TestNewMapFromReaderDistances() {
+	m, err := NewMapFromReader(strings.NewReader("Foo north=Bar:3 west=Baz:1\nBar south=Foo:2\n"))
+	s.Nil(err)
+	s.Equal("Bar south=Foo:2\nFoo north=Bar:3 west=Baz\n", m.ToString())
+	s.Equal(3, m.RoadAttributes("Foo", "Bar").Distance)
+	s.Equal(1, m.RoadAttributes("Foo", "Baz").Distance)
+	s.Len(m.Roads, 2)
+
+	_, err = NewMapFromReader(strings.NewReader("Foo north=Bar:0\n"))
+	s.ErrorIs(err, ErrorInvalidDistance)
+
+	m, err = NewMapFromReaderWithOptions(strings.NewReader("Foo north=Bar:3\n"), MapOptions{Symmetric: true})
+	s.Nil(err)
+	s.Equal("Bar south=Foo:3\nFoo north=Bar:3\n", m.ToString())
+
+	s.Nil(m.DestroyCity(m.Cities["Bar"]))
+	s.Empty(m.Roads)
+}
+
+func (s *RoadTestSuite) TestDocumentDistances() {
+	m, err := ReadMap(strings.NewReader(`{"roads": [{"from": "Foo", "to": "Bar", "direction": "north", "distance": 4}, {"from": "Bar", "to": "Foo", "direction": "south"}]}`), MapOptions{Format: "json"})
+	s.Nil(err)
+	s.Equal("Bar south=Foo\nFoo north=Bar:4\n", m.ToString())
+	s.Equal([]RoadDocument{
+		{From: "Bar", To: "Foo", Direction: "south"},
+		{From: "Foo", To: "Bar", Direction: "north", Distance: 4},
+	}, m.ToDocument().Roads)
+
+	_, err = ReadMap(strings.NewReader(`{"roads": [{"from": "Foo", "to": "Bar", "direction": "north", "distance": -1}]}`), MapOptions{Format: "json"})
+	s.ErrorIs(err, ErrorInvalidMapDocument)
+
+	m, err = ReadMap(strings.NewReader("roads:\n  - {from: Foo, to: Bar, direction: north, capacity: 2, durability: 5, failure: 0.5}\n"), MapOptions{Format: "yaml"})
+	s.Nil(err)
+	s.Equal(RoadAttributes{Distance: 1, Capacity: 2, Durability: 5, Failure: 0.5}, m.RoadAttributes("Foo", "Bar"))
+	s.Equal("Foo north=Bar:capacity=2:durability=5:failure=0.5\n", m.ToString())
+	_, err = ReadMap(strings.NewReader(`{"roads": [{"from": "Foo", "to": "Bar", "direction": "north", "failure": 2}]}`), MapOptions{Format: "json"})
+	s.ErrorIs(err, ErrorInvalidMapDocument)
+}
+
+// buildLongRoadSim builds a simulator over the text map with an always moving alien on each of the cities.
+func buildLongRoadSim(text string, alienCities []string) (*AlienSimulator, *recordingSink) {
+	m, _ := NewMapFromReader(strings.NewReader(text))
+	aliens := []*Alien{}
+	for i, city := range alienCities {
+		alien := NewAlien(i, string(rune('a'+i)), city, m)
+		aliens = append(aliens, &alien)
+	}
+	sink := &recordingSink{}
+	sim := NewAlienSimulator(m, aliens, 10, false)
+	sim.Rand = rand.New(rand.NewSource(1))
+	sim.Sink = sink
+	sim.Movement = AlwaysMoveStrategy{}
+	sim.TrackOccupancy = true
+	return &sim, sink
+}
+
+func (s *RoadTestSuite) TestAlienOnRoad() {
+	for _, mode := range []TickMode{TickSequential, TickSynchronous} {
+		s.Run(string(mode), func() {
+			sim, sink := buildLongRoadSim("x east=y:3\n", []string{"x"})
+			sim.TickMode = mode
+			alien := sim.Aliens[0]
+			tick := func(iteration int) {
+				sim.CurrentIteration = iteration
+				if mode == TickSequential {
+					s.Nil(sim.sequentialTick())
+				} else {
+					s.Nil(sim.synchronousTick())
+				}
+			}
+
+			tick(0)
+			s.Equal(&Transit{From: "x", To: "y", Direction: "east", Arrival: 2}, alien.Transit)
+			s.Empty(sim.Map.graphCity("x").Aliens)
+			s.Empty(sim.Map.graphCity("y").Aliens)
+			s.Equal(0, alien.NumMovements)
+			departed := sink.ofType(EventAlienDeparted)
+			s.Len(departed, 1)
+			s.Equal(2, departed[0].Arrival)
+			s.Contains(alien.ToString(), "On Road: east from x to y until iteration 2")
+			report := sim.Report()
+			s.Equal(alien.Transit, report.Aliens[0].OnRoad)
+			s.Contains(report.ToString(), "x east=y:3")
+
+			tick(1)
+			s.NotNil(alien.Transit)
+			s.Empty(sink.ofType(EventAlienMoved))
+
+			tick(2)
+			s.Nil(alien.Transit)
+			s.Equal("y", alien.CurrentCityName)
+			s.Equal(1, alien.NumMovements)
+			s.Equal([]*Alien{alien}, sim.Map.graphCity("y").Aliens)
+			moved := sink.ofType(EventAlienMoved)
+			s.Len(moved, 1)
+			s.Equal("x", moved[0].FromCity)
+			s.Nil(sim.Report().Aliens[0].OnRoad)
+		})
+	}
+}
+
+func (s *RoadTestSuite) TestDestroyedCityKillsAliensOnItsRoads() {
+	sim, sink := buildLongRoadSim("a east=b:5\nc west=b\nd north=b\n", []string{"a", "c", "d"})
+	s.Nil(sim.SimulateInvasion())
+	s.Equal(StopAllAliensDead, sim.StopReason)
+	destroyed := sink.ofType(EventCityDestroyed)
+	s.Len(destroyed, 1)
+	s.Equal("b", destroyed[0].City)
+	s.Equal([]string{"b", "c"}, destroyed[0].Aliens)
+	s.Equal([]string{"b", "c", "a"}, destroyed[0].Dead)
+	s.Equal(3, sim.NumDeadAliens)
+	s.Nil(sim.Aliens[0].Transit)
+	s.True(sim.Aliens[0].IsDead)
+}
+
+func (s *RoadTestSuite) TestNearestRoadStrategy() {
+	m, _ := NewMapFromReader(strings.NewReader("start north=near east=far:50\n"))
+	paths, _ := m.GetPaths(m.Cities["start"])
+	roads := sortedRoads(paths)
+	alien := NewAlien(0, "alien", "start", m)
+	rng := rand.New(rand.NewSource(3))
+	strategy := NearestRoadStrategy{}
+	near := 0
+	for i := 0; i < 100; i++ {
+		if strategy.ChooseRoad(&alien, roads, rng).To.Data.Name == "near" {
+			near++
+		}
+	}
+	s.Greater(near, 90)
+}
+
+func (s *RoadTestSuite) TestMapDestroyRoad() {
+	m, err := NewMapFromReader(strings.NewReader("Foo north=Bar:3 west=Baz\nBar south=Foo\n"))
+	s.Nil(err)
+	s.Nil(m.DestroyRoad("Foo", "Bar"))
+	paths, _ := m.GetPaths(m.Cities["Foo"])
+	s.Len(paths, 1)
+	s.Empty(m.Roads)
+	s.Equal([]string{"Bar", "Baz", "Foo"}, m.GetCitiesNames())
+	s.Equal("Bar south=Foo\nFoo west=Baz\n", m.ToString())
+	s.ErrorIs(m.DestroyRoad("Foo", "Bar"), ErrorRoadDoesNotExists)
+	s.ErrorIs(m.DestroyRoad("Foo", "Nowhere"), ErrorRoadDoesNotExists)
+}
+
+func (s *RoadTestSuite) TestRoadsCollapse() {
+	vals := []struct {
+		name      string
+		text      string
+		destroyed []DestroyedRoad
+		want      string
+	}{
+		{
+			name:      "worn out after the last traversal",
+			text:      "x east=y:durability=1\ny west=x\n",
+			destroyed: []DestroyedRoad{{From: "x", To: "y", Direction: "east", Iteration: 0, Cause: CauseRoadWornOut}},
+			want:      "y west=x\n",
+		},
+		{
+			name: "durability goes down",
+			text: "x east=y:durability=3\ny west=x\n",
+			want: "x east=y:durability=2\ny west=x\n",
+		},
+		{
+			name:      "always failing road",
+			text:      "x east=y:failure=1\ny west=x\n",
+			destroyed: []DestroyedRoad{{From: "x", To: "y", Direction: "east", Iteration: 0, Cause: CauseRoadFailure}},
+			want:      "y west=x\n",
+		},
+		{
+			name: "never failing road",
+			text: "x east=y:failure=0\ny west=x\n",
+			want: "x east=y\ny west=x\n",
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sim, sink := buildLongRoadSim(val.text, []string{"x"})
+			s.Nil(sim.sequentialTick())
+			s.Equal("y", sim.Aliens[0].CurrentCityName)
+			s.Equal(val.want, sim.Map.ToString())
+			s.Equal(val.destroyed, sim.DestroyedRoads)
+			s.Equal(len(val.destroyed), len(sink.ofType(EventRoadDestroyed)))
+			if len(val.destroyed) > 0 {
+				s.Equal(val.destroyed, sim.Report().DestroyedRoads)
+				s.Contains(sim.Report().ToString(), fmt.Sprintf("Destroyed roads:\n  east from x to y on iteration 0 (%s)\n", val.destroyed[0].Cause))
+			}
+		})
+	}
+}
+
+func (s *RoadTestSuite) TestDestroyRoadKillsAliensOnIt() {
+	sim, sink := buildLongRoadSim("x east=y:3\n", []string{"x"})
+	s.Nil(sim.sequentialTick())
+	s.NotNil(sim.Aliens[0].Transit)
+	s.Nil(sim.DestroyRoad("x", "y", "sabotage"))
+	s.True(sim.Aliens[0].IsDead)
+	s.Equal(1, sim.NumDeadAliens)
+	events := sink.ofType(EventRoadDestroyed)
+	s.Len(events, 1)
+	s.Equal(Event{Type: EventRoadDestroyed, FromCity: "x", ToCity: "y", Direction: "east", Dead: []string{"a"}, Cause: "sabotage"}, events[0])
+	s.ErrorIs(sim.DestroyRoad("x", "y", "sabotage"), ErrorRoadDoesNotExists)
+	s.Empty(sim.roadTransit)
+}
+
+func (s *RoadTestSuite) TestRoadCapacity() {
+	vals := []struct {
+		name string
+		mode TickMode
+		text string
+		// cities are the cities of both aliens after every iteration
+		cities [][2]string
+	}{
+		{
+			name:   "sequential instant road",
+			mode:   TickSequential,
+			text:   "x east=y:capacity=1\n",
+			cities: [][2]string{{"y", "x"}, {"y", "y"}},
+		},
+		{
+			name:   "synchronous instant road",
+			mode:   TickSynchronous,
+			text:   "x east=y:capacity=1\n",
+			cities: [][2]string{{"y", "x"}, {"y", "y"}},
+		},
+		{
+			name:   "long road stays full while the alien travels",
+			mode:   TickSequential,
+			text:   "x east=y:2:capacity=1\n",
+			cities: [][2]string{{"x", "x"}, {"y", "x"}, {"y", "y"}},
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sim, sink := buildLongRoadSim(val.text, []string{"x", "x"})
+			sim.Collision = ThresholdCollision{Threshold: 3}
+			sim.TickMode = val.mode
+			for i, cities := range val.cities {
+				sim.CurrentIteration = i
+				if val.mode == TickSequential {
+					s.Nil(sim.sequentialTick())
+				} else {
+					s.Nil(sim.synchronousTick())
+				}
+				s.Equal(cities, [2]string{sim.Aliens[0].CurrentCityName, sim.Aliens[1].CurrentCityName}, "iteration %d", i)
+			}
+			stayed := sink.ofType(EventAlienStayed)
+			s.Len(stayed, 1)
+			s.Equal("b", stayed[0].AlienName)
+			s.Equal(0, stayed[0].Iteration)
+		})
+	}
+}
+
+// TestRoadCapacityArrival tests that a road is open on the iteration its alien arrives, even for the aliens moving
+// before it on the sequential tick mode
+func (s *RoadTestSuite) TestRoadCapacityArrival() {
+	// a reaches x on iteration 0 while b takes the road to y, and a takes it on iteration 1 before b arrives
+	sim, sink := buildLongRoadSim("z east=x\nx east=y:2:capacity=1\n", []string{"z", "x"})
+	sim.Collision = ThresholdCollision{Threshold: 3}
+	cities := [][2]string{{"x", "x"}, {"x", "y"}, {"y", "y"}}
+	for i, want := range cities {
+		sim.CurrentIteration = i
+		s.Nil(sim.sequentialTick())
+		s.Equal(want, [2]string{sim.Aliens[0].CurrentCityName, sim.Aliens[1].CurrentCityName}, "iteration %d", i)
+	}
+	s.Empty(sink.ofType(EventAlienStayed))
+	departed := sink.ofType(EventAlienDeparted)
+	s.Len(departed, 2)
+	s.Equal("b", departed[0].AlienName)
+	s.Equal(0, departed[0].Iteration)
+	s.Equal("a", departed[1].AlienName)
+	s.Equal(1, departed[1].Iteration)
+	s.Empty(sim.roadTransit)
+}
+
+func (s *RoadTestSuite) TestShortestRoute() {
+	m, _ := NewMapFromReader(strings.NewReader("a east=b:5 north=c\nb east=d\nc east=e:2\ne south=d:2\nd\nf\n"))
+	route, distance, err := m.ShortestRoute("a", "d")
+	s.Nil(err)
+	s.Equal([]string{"a", "c", "e", "d"}, route)
+	s.Equal(5, distance)
+
+	s.Nil(m.DestroyRoad("c", "e"))
+	route, distance, err = m.ShortestRoute("a", "d")
+	s.Nil(err)
+	s.Equal([]string{"a", "b", "d"}, route)
+	s.Equal(6, distance)
+
+	_, _, err = m.ShortestRoute("a", "f")
+	s.ErrorIs(err, graph.ErrorNoPath)
+	_, _, err = m.ShortestRoute("a", "z")
+	s.ErrorIs(err, ErrorCityDoesNotExists)
+}
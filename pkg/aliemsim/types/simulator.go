@@ -2,9 +2,11 @@ package types
 
 import (
 	"alien-invasion-simulator/pkg/graph"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -20,10 +22,37 @@ type AlienSimulator struct {
 	NumAliensCannotMove      int
 	Verbose                  bool
 	Seed                     int64
+	Rand                     *rand.Rand
+	Sink                     EventSink
+	StopReason               StopReason
+	DestroyedCities          []DestroyedCity
+	DestroyedRoads           []DestroyedRoad
+	Collision                CollisionPolicy
+	Movement                 MovementStrategy
+	TickMode                 TickMode
+	// SpawnCollision decides what happens with the aliens landing on the same city. They coexist when empty.
+	SpawnCollision SpawnCollision
+	// Stop ends the simulation early once one of its conditions is met.
+	Stop StopConditions
+	// TrackOccupancy counts the aliens on a city from the moment they land on it until they leave it. When false
+	// only the aliens that moved into a city count on it, and they keep counting after leaving until they die.
+	TrackOccupancy bool
+	// PublishSnapshots takes a snapshot of the map before every iteration and when the simulation ends, read the last
+	// one with Snapshot while the simulation runs.
+	PublishSnapshots bool
+	published        *publishedSnapshot
+	// roadEntries counts the aliens that entered each road on the current iteration and are not travelling on it.
+	roadEntries map[graph.EdgeId]int
+	// roadTransit counts the aliens travelling on each road by the iteration they arrive.
+	roadTransit map[graph.EdgeId]map[int]int
 }
 
-// NewAlienSimulator creates a new alien invasion simulator.
+// NewAlienSimulator creates a new alien invasion simulator. The random source is seeded from the clock,
+// set Seed and Rand to make the invasion reproducible. Events are logged until Sink is replaced. Cities are destroyed
+// when 2 aliens meet on them until Collision is replaced, and aliens without strategy do a random walk until Movement
+// is replaced.
 func NewAlienSimulator(mapData *Map[City, Direction], aliens []*Alien, maxIterations int, verbose bool) AlienSimulator {
+	seed := time.Now().UTC().UnixNano()
 	return AlienSimulator{
 		Map:                      mapData,
 		Aliens:                   aliens,
@@ -33,7 +62,70 @@ func NewAlienSimulator(mapData *Map[City, Direction], aliens []*Alien, maxIterat
 		NumDeadAliens:            0,
 		NumAliensCannotMove:      0,
 		CurrentIteration:         0,
+		Seed:                     seed,
+		Rand:                     rand.New(rand.NewSource(seed)),
+		Sink:                     LogSink{Verbose: verbose},
+		Collision:                ThresholdCollision{Threshold: 2},
+		Movement:                 RandomWalkStrategy{},
+		TickMode:                 TickSequential,
+		published:                &publishedSnapshot{},
+	}
+}
+
+// publishedSnapshot holds the last snapshot of the map published by the simulation.
+type publishedSnapshot struct {
+	mu       sync.Mutex
+	snapshot *graph.Snapshot[City, Direction]
+}
+
+// Snapshot returns the last snapshot of the map published by the simulation, or nil when none was published. It is
+// safe to call from any goroutine, the snapshots are taken by the simulation between iterations.
+func (sim *AlienSimulator) Snapshot() *graph.Snapshot[City, Direction] {
+	if sim.published == nil {
+		return nil
+	}
+	sim.published.mu.Lock()
+	defer sim.published.mu.Unlock()
+	return sim.published.snapshot
+}
+
+// publishSnapshot takes a snapshot of the map for Snapshot when PublishSnapshots is set.
+func (sim *AlienSimulator) publishSnapshot() {
+	if !sim.PublishSnapshots || sim.published == nil {
+		return
+	}
+	snapshot := sim.Map.Snapshot()
+	sim.published.mu.Lock()
+	defer sim.published.mu.Unlock()
+	sim.published.snapshot = snapshot
+}
+
+// strategyFor returns the movement strategy of an alien.
+func (sim *AlienSimulator) strategyFor(alien *Alien) MovementStrategy {
+	if alien.Strategy != nil {
+		return alien.Strategy
+	}
+	return sim.Movement
+}
+
+// emit stamps the event with the current iteration and sends it to the simulator sink.
+func (sim *AlienSimulator) emit(event Event) {
+	if sim.Sink == nil {
+		return
 	}
+	event.Iteration = sim.CurrentIteration
+	sim.Sink.Emit(event)
+}
+
+// stop records why the simulation ended and emits the SimulationEnded event.
+func (sim *AlienSimulator) stop(reason StopReason) {
+	sim.StopReason = reason
+	sim.publishSnapshot()
+	event := Event{Type: EventSimulationEnded, Reason: reason}
+	if sim.Sink != nil {
+		event.Summary = fmt.Sprintf("Finished Simulation. Map is: \n------- \n\n%s \n%s", sim.Map.ToString(), sim.getStats())
+	}
+	sim.emit(event)
 }
 
 // printStats logs string information of the current simulation object.
@@ -52,109 +144,388 @@ func (sim *AlienSimulator) getStats() string {
 func (sim *AlienSimulator) SimulateInvasion() error {
 	sim.CurrentIteration = 0
 	if len(sim.Map.Cities) == 0 {
-		log.Print("No cities on map. Stopping")
+		sim.stop(StopNoCities)
 		return nil
 	}
+	sim.roadTransit = map[graph.EdgeId]map[int]int{}
+	for _, alien := range sim.Aliens {
+		if alien.Transit != nil && !alien.IsDead {
+			sim.addTransit(alien.Transit, 1)
+		}
+		// fights at landing need to know which aliens landed together
+		if sim.TrackOccupancy || sim.SpawnCollision == SpawnFight {
+			if city := sim.Map.graphCity(alien.CurrentCityName); city != nil && !alien.IsDead && !city.hasAlien(alien) {
+				city.Aliens = append(city.Aliens, alien)
+			}
+		}
+		sim.emit(Event{Type: EventAlienSpawned, AlienID: alien.ID, AlienName: alien.Name, City: alien.CurrentCityName})
+	}
+	if sim.SpawnCollision == SpawnFight {
+		sim.fightAtSpawn()
+		if len(sim.Map.Cities) == 0 {
+			sim.stop(StopNoCities)
+			return nil
+		}
+	}
 	for true {
+		sim.publishSnapshot()
 		if sim.Verbose {
 			stats := sim.getStats()
 			log.Printf("%s", stats)
 
 		}
 
-		for _, alien := range sim.Aliens {
-			if sim.Verbose {
-				log.Printf("%s", alien.ToString())
-			}
-			if alien.IsDead {
-				continue
-			}
-			if alien.NumMovements == sim.MaxIterations && alien.CanMove {
-				sim.NumAliensReachedMaxMoves += 1
-				sim.NumAliensCannotMove += 1
-				alien.CanMove = false
-				continue
-			}
-			// each alien randomly decides to invade a city.
-
-			willMove := sim.alienWillMove()
-			if willMove {
-				_, err := sim.alienMove(alien)
-				if err != nil {
-					return err
-				}
-			}
-
+		var err error
+		if sim.TickMode == TickSynchronous {
+			err = sim.synchronousTick()
+		} else {
+			err = sim.sequentialTick()
+		}
+		if err != nil {
+			return err
 		}
 		if len(sim.Map.Cities) == 0 {
-			log.Print("No more cities left. Stopping simulation.")
+			sim.stop(StopNoCities)
 			break
 		}
 		if sim.NumDeadAliens == len(sim.Aliens) {
-			log.Print("All aliens are dead. Stopping simulation.")
+			sim.stop(StopAllAliensDead)
+			break
+		}
+		if stopper, ok := sim.Collision.(collisionStopper); ok {
+			if reason, done := stopper.stopReason(sim); done {
+				sim.stop(reason)
+				break
+			}
+		}
+		if reason, done := sim.Stop.reached(sim); done {
+			sim.stop(reason)
 			break
 		}
 		if sim.NumAliensReachedMaxMoves >= len(sim.Aliens) {
-			log.Printf("All aliens have moved %d times. Stopping simulation.", sim.MaxIterations)
+			sim.stop(StopAllAliensMaxMoves)
 			break
 		}
 		if sim.NumAliensCannotMove == len(sim.Aliens) {
-			log.Print("All aliens done. Stopping simulation.")
+			sim.stop(StopAllAliensDone)
 			break
 		}
 		sim.CurrentIteration += 1
 	}
-	log.Printf("Finished Simulation. Map is: \n------- \n\n%s \n", sim.Map.ToString())
-	stats := sim.getStats()
-	log.Printf("%s", stats)
 	return nil
 }
 
-// alienWillMove decides randomly if an alien will move.
-func (sim *AlienSimulator) alienWillMove() bool {
-	seed := time.Now().UTC().UnixNano()
-	rand.Seed(seed)
-	return rand.Intn(2) == 1
+// sequentialTick moves the aliens one at a time in slice order, every alien sees the moves of the previous ones.
+// Aliens on a road spend their turn travelling, and reaching the end of the road is their move.
+func (sim *AlienSimulator) sequentialTick() error {
+	sim.roadEntries = map[graph.EdgeId]int{}
+	for _, alien := range sim.Aliens {
+		if sim.Verbose {
+			log.Printf("%s", alien.ToString())
+		}
+		if alien.Transit != nil {
+			if city := sim.arrive(alien); city != nil {
+				sim.collide(city)
+			}
+			continue
+		}
+		if !sim.alienWillMove(alien) {
+			continue
+		}
+		_, err := sim.alienMove(alien)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alienWillMove decides following its strategy if an alien tries to move on this iteration. Aliens reaching the max
+// number of moves and aliens that stay put are marked as not able to move.
+func (sim *AlienSimulator) alienWillMove(alien *Alien) bool {
+	if alien.IsDead {
+		return false
+	}
+	if alien.NumMovements == sim.MaxIterations && alien.CanMove {
+		sim.NumAliensReachedMaxMoves += 1
+		sim.NumAliensCannotMove += 1
+		alien.CanMove = false
+		sim.emit(Event{Type: EventAlienReachedMaxMoves, AlienID: alien.ID, AlienName: alien.Name, City: alien.CurrentCityName, NumMovements: alien.NumMovements})
+		return false
+	}
+	strategy := sim.strategyFor(alien)
+	if strategy == StayPut {
+		if alien.CanMove {
+			alien.CanMove = false
+			sim.NumAliensCannotMove += 1
+		}
+		return false
+	}
+	return strategy.WillMove(alien, sim.Rand)
 }
 
-// alienMove simulates an alien movement, destroying a city and aliens if more than 2 aliens collide.
+// alienMove simulates an alien movement on the road chosen by its strategy, and applies the collision policy on the
+// invaded city.
 func (sim *AlienSimulator) alienMove(alien *Alien) (*City, error) {
+	road, err := sim.chooseRoad(alien)
+	if err != nil {
+		return nil, err
+	}
+	if road == nil {
+		return sim.Map.Cities[alien.CurrentCityName], nil
+	}
+	invadedCity := sim.travel(alien, road)
+	if invadedCity != nil {
+		sim.collide(invadedCity)
+	}
+	return invadedCity, nil
+}
+
+// chooseRoad returns the road the alien strategy takes from its city. Returns nil when the alien is trapped or stays,
+// both count as a movement.
+func (sim *AlienSimulator) chooseRoad(alien *Alien) (*Road, error) {
 	city := sim.Map.Cities[alien.CurrentCityName]
 
 	trapped, err := alien.isTrapped()
+	if errors.Is(err, ErrorCityDoesNotExists) && sim.cityDestroyed(alien.CurrentCityName) {
+		// without TrackOccupancy aliens do not count on the city they landed on, so they outlive it on its ruins
+		trapped, err = true, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 	if trapped {
-		if sim.Verbose {
-			log.Printf("Alien %s is trapped on %v! [Movement #%d]", alien.Name, alien.CurrentCityName, alien.NumMovements)
-		}
+		sim.emit(Event{Type: EventAlienTrapped, AlienID: alien.ID, AlienName: alien.Name, City: alien.CurrentCityName, NumMovements: alien.NumMovements})
 		alien.NumMovements += 1
-		return city, nil
+		return nil, nil
 	}
 	paths, _ := sim.Map.GetPaths(city)
-	var pathKeys []graph.VertexID
-	for k, _ := range paths {
-		pathKeys = append(pathKeys, k)
+	// map iteration order is random, roads are sorted so the chosen path only depends on sim.Rand
+	var road *Road
+	if roads := sim.openRoads(sortedRoads(paths)); len(roads) > 0 {
+		road = sim.strategyFor(alien).ChooseRoad(alien, roads, sim.Rand)
+	}
+	if road == nil {
+		sim.emit(Event{Type: EventAlienStayed, AlienID: alien.ID, AlienName: alien.Name, City: alien.CurrentCityName, NumMovements: alien.NumMovements})
+		alien.NumMovements += 1
+		return nil, nil
+	}
+	if sim.roadEntries == nil {
+		sim.roadEntries = map[graph.EdgeId]int{}
+	}
+	sim.roadEntries[road.Id()]++
+	return road, nil
+}
+
+// openRoads returns the roads that are not at full capacity. Aliens entering a road on this iteration and aliens
+// still travelling on it count.
+func (sim *AlienSimulator) openRoads(roads []*Road) []*Road {
+	open := []*Road{}
+	for _, road := range roads {
+		capacity := sim.Map.RoadAttributes(road.From.Data.Name, road.To.Data.Name).Capacity
+		if capacity == 0 || sim.roadLoad(road) < capacity {
+			open = append(open, road)
+		}
+	}
+	return open
+}
+
+// roadLoad returns the number of aliens on a road on this iteration. Aliens arriving on this iteration already left
+// it, even when they did not move yet.
+func (sim *AlienSimulator) roadLoad(road *Road) int {
+	load := sim.roadEntries[road.Id()]
+	for arrival, count := range sim.roadTransit[road.Id()] {
+		if arrival > sim.CurrentIteration {
+			load += count
+		}
+	}
+	return load
+}
+
+// addTransit adds count aliens travelling on the road of the trip. Use a negative count once they leave it.
+func (sim *AlienSimulator) addTransit(transit *Transit, count int) {
+	id := graph.EdgeId{From: graph.VertexID(transit.From), To: graph.VertexID(transit.To)}
+	if sim.roadTransit == nil {
+		sim.roadTransit = map[graph.EdgeId]map[int]int{}
+	}
+	arrivals, ok := sim.roadTransit[id]
+	if !ok {
+		arrivals = map[int]int{}
+		sim.roadTransit[id] = arrivals
 	}
-	chosenPathKey := pathKeys[rand.Intn(len(pathKeys))]
-	chosenPath := paths[chosenPathKey]
+	arrivals[transit.Arrival] += count
+	if arrivals[transit.Arrival] == 0 {
+		delete(arrivals, transit.Arrival)
+	}
+	if len(arrivals) == 0 {
+		delete(sim.roadTransit, id)
+	}
+}
+
+// travel moves an alien out of its city along the road and into the city at the end of it. Aliens on roads longer
+// than 1 stay on the road until they arrive, and nil is returned.
+func (sim *AlienSimulator) travel(alien *Alien, road *Road) *City {
 	prevCity := alien.CurrentCityName
-	invadedCity := alien.invadeCity(&chosenPath.To.Data)
-	if len(invadedCity.Aliens) == 2 {
-		log.Printf("paths are %v. chose path was %v. current city of alien is %s", pathKeys, chosenPath, prevCity)
-		aliensBefore := []*Alien{}
-		aliensBefore = append(aliensBefore, invadedCity.Aliens[0])
-		aliensBefore = append(aliensBefore, invadedCity.Aliens[1])
-		sim.Map.DestroyCity(invadedCity)
-		log.Printf("[DESTROYED] Aliens %s and %s are fighting! City %s is destroyed.",
-			aliensBefore[0].Name,
-			aliensBefore[1].Name,
-			invadedCity.Name)
+	if fromCity := sim.Map.graphCity(prevCity); fromCity != nil && sim.TrackOccupancy {
+		fromCity.removeAlien(alien)
+	}
+	if distance := sim.Map.RoadDistance(road); distance > 1 {
+		alien.Transit = &Transit{From: prevCity, To: road.To.Data.Name, Direction: road.Data, Departure: sim.CurrentIteration,
+			Arrival: sim.CurrentIteration + distance - 1}
+		if sim.roadEntries[road.Id()] > 0 {
+			sim.roadEntries[road.Id()]--
+		}
+		sim.addTransit(alien.Transit, 1)
+		sim.emit(Event{
+			Type:         EventAlienDeparted,
+			AlienID:      alien.ID,
+			AlienName:    alien.Name,
+			FromCity:     prevCity,
+			ToCity:       alien.Transit.To,
+			Direction:    road.Data,
+			NumMovements: alien.NumMovements,
+			Arrival:      alien.Transit.Arrival,
+		})
+		return nil
+	}
+	return sim.enterCity(alien, prevCity, &road.To.Data, road.Data)
+}
+
+// arrive moves an alien on a road into the city at its end once the arrival iteration is reached. Returns nil
+// while the alien is still on the road.
+func (sim *AlienSimulator) arrive(alien *Alien) *City {
+	transit := alien.Transit
+	if transit == nil || sim.CurrentIteration < transit.Arrival {
+		return nil
+	}
+	alien.Transit = nil
+	sim.addTransit(transit, -1)
+	city := sim.Map.graphCity(transit.To)
+	if city == nil {
+		// aliens on the roads of destroyed cities die with them, so the city is always there
+		return nil
+	}
+	return sim.enterCity(alien, transit.From, city, transit.Direction)
+}
+
+// enterCity moves an alien into a city it reached from another one, and wears out the road it came on.
+func (sim *AlienSimulator) enterCity(alien *Alien, prevCity string, city *City, dir Direction) *City {
+	invadedCity := alien.invadeCity(city)
+	sim.emit(Event{
+		Type:         EventAlienMoved,
+		AlienID:      alien.ID,
+		AlienName:    alien.Name,
+		FromCity:     prevCity,
+		ToCity:       invadedCity.Name,
+		Direction:    dir,
+		NumMovements: alien.NumMovements,
+	})
+	sim.wearRoad(prevCity, invadedCity.Name)
+	return invadedCity
+}
+
+// wearRoad records a traversal of the road and destroys it when it runs out of durability or fails. Roads without
+// a failure probability do not use the random source.
+func (sim *AlienSimulator) wearRoad(fromCityName, toCityName string) {
+	attrs := sim.Map.RoadAttributes(fromCityName, toCityName)
+	if sim.Map.wearRoad(fromCityName, toCityName) {
+		_ = sim.DestroyRoad(fromCityName, toCityName, CauseRoadWornOut)
+		return
+	}
+	if attrs.Failure > 0 && sim.Rand.Float64() < attrs.Failure {
+		_ = sim.DestroyRoad(fromCityName, toCityName, CauseRoadFailure)
+	}
+}
+
+// DestroyRoad destroys the road between 2 cities with the given cause. Aliens travelling on it die, and a
+// RoadDestroyed event is emitted.
+func (sim *AlienSimulator) DestroyRoad(fromCityName, toCityName string, cause string) error {
+	road := sim.Map.Graph.GetEdge(graph.VertexID(fromCityName), graph.VertexID(toCityName))
+	if road == nil {
+		return fmt.Errorf("%w '%s' to '%s'", ErrorRoadDoesNotExists, fromCityName, toCityName)
+	}
+	dir := road.Data
+	if err := sim.Map.DestroyRoad(fromCityName, toCityName); err != nil {
+		return err
+	}
+	dead := sim.killAliensOnRoads(func(t *Transit) bool { return t.From == fromCityName && t.To == toCityName })
+	sim.NumDeadAliens += len(dead)
+	sim.NumAliensCannotMove += len(dead)
+	sim.DestroyedRoads = append(sim.DestroyedRoads, DestroyedRoad{From: fromCityName, To: toCityName, Direction: dir, Iteration: sim.CurrentIteration, Cause: cause})
+	sim.emit(Event{Type: EventRoadDestroyed, FromCity: fromCityName, ToCity: toCityName, Direction: dir, Dead: dead, Cause: cause})
+	return nil
+}
+
+// fightAtSpawn applies the collision policy on every city where more than one alien landed, in city name order.
+func (sim *AlienSimulator) fightAtSpawn() {
+	for _, name := range sim.Map.GetCitiesNames() {
+		if city := sim.Map.graphCity(name); city != nil && len(city.Aliens) > 1 {
+			sim.collide(city)
+		}
+	}
+}
+
+// collide applies the collision policy on a city that an alien just arrived to. The aliens counted on the city are the
+// fighters, and the ones the policy kills are removed from the city. Aliens on the roads of a destroyed city die too.
+func (sim *AlienSimulator) collide(city *City) {
+	// without TrackOccupancy aliens keep counting on the cities they left, and might have died elsewhere
+	city.removeDeadAliens()
+	collision := sim.Collision.Resolve(city, sim.Rand)
+	if !collision.DestroyCity && len(collision.Dead) == 0 {
+		return
+	}
+	fighters := []string{}
+	for _, alien := range city.Aliens {
+		fighters = append(fighters, alien.Name)
+	}
+	killed := collision.Dead
+	if collision.DestroyCity {
+		killed = append([]*Alien{}, city.Aliens...)
+	}
+	dead := []string{}
+	for _, alien := range killed {
+		city.removeAlien(alien)
 		alien.IsDead = true
-		sim.NumDeadAliens += 2
-		sim.NumAliensCannotMove += 2
+		if alien.Transit != nil {
+			sim.addTransit(alien.Transit, -1)
+			alien.Transit = nil
+		}
+		dead = append(dead, alien.Name)
+	}
+	if collision.DestroyCity {
+		dead = append(dead, sim.killAliensOnRoads(func(t *Transit) bool { return t.From == city.Name || t.To == city.Name })...)
+		sim.Map.DestroyCity(city)
+		sim.DestroyedCities = append(sim.DestroyedCities, DestroyedCity{Name: city.Name, Iteration: sim.CurrentIteration, Aliens: fighters})
+		sim.emit(Event{Type: EventCityDestroyed, City: city.Name, Aliens: fighters, Dead: dead, Cause: CauseAlienFight})
+	} else {
+		sim.emit(Event{Type: EventAliensFought, City: city.Name, Aliens: fighters, Dead: dead})
+	}
+	sim.NumDeadAliens += len(dead)
+	sim.NumAliensCannotMove += len(dead)
+}
 
+// cityDestroyed returns whether the city was destroyed by the simulation.
+func (sim *AlienSimulator) cityDestroyed(cityName string) bool {
+	for _, city := range sim.DestroyedCities {
+		if city.Name == cityName {
+			return true
+		}
 	}
-	return invadedCity, nil
+	return false
+}
+
+// killAliensOnRoads kills the aliens travelling on the roads matched by onRoad and returns their names. The caller
+// counts them as dead.
+func (sim *AlienSimulator) killAliensOnRoads(onRoad func(*Transit) bool) []string {
+	dead := []string{}
+	for _, alien := range sim.Aliens {
+		if alien.IsDead || alien.Transit == nil || !onRoad(alien.Transit) {
+			continue
+		}
+		alien.IsDead = true
+		sim.addTransit(alien.Transit, -1)
+		alien.Transit = nil
+		dead = append(dead, alien.Name)
+	}
+	return dead
 }
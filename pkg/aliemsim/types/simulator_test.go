@@ -4,6 +4,8 @@ import (
 	"alien-invasion-simulator/pkg/graph"
 	"fmt"
 	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -340,3 +342,162 @@ func (s *SimulatorTestSuite) TestAlienMove() {
 		})
 	}
 }
+
+func (s *SimulatorTestSuite) TestSimulateInvasionSameSeed() {
+	build := func() AlienSimulator {
+		m, _ := NewMapFromReader(strings.NewReader("a north=b east=c\nb south=a west=d\nc west=a north=d\nd east=b south=c\n"))
+		aliens := []*Alien{}
+		for i, city := range []string{"a", "d"} {
+			alien := NewAlien(i, fmt.Sprintf("alien%d", i), city, m)
+			aliens = append(aliens, &alien)
+		}
+		sim := NewAlienSimulator(m, aliens, 20, false)
+		sim.Seed = 7
+		sim.Rand = rand.New(rand.NewSource(sim.Seed))
+		return sim
+	}
+	sim1 := build()
+	sim2 := build()
+	s.Nil(sim1.SimulateInvasion())
+	s.Nil(sim2.SimulateInvasion())
+	s.Equal(sim1.getStats(), sim2.getStats())
+	s.Equal(sim1.Map.ToString(), sim2.Map.ToString())
+	for i := range sim1.Aliens {
+		s.Equal(sim1.Aliens[i].ToString(), sim2.Aliens[i].ToString())
+	}
+}
+
+// TestSimulateInvasionTrackOccupancy tests that the aliens standing on a city only count on it with TrackOccupancy
+func (s *SimulatorTestSuite) TestSimulateInvasionTrackOccupancy() {
+	vals := []struct {
+		name           string
+		trackOccupancy bool
+		numDeadAliens  int
+		cities         []string
+	}{
+		{name: "landed aliens do not count", trackOccupancy: false, numDeadAliens: 0, cities: []string{"a", "b"}},
+		{name: "landed aliens count", trackOccupancy: true, numDeadAliens: 2, cities: []string{"a"}},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			m, _ := NewMapFromReader(strings.NewReader("a north=b\nb\n"))
+			alien1 := NewAlien(0, "alien1", "a", m)
+			alien2 := NewAlien(1, "alien2", "b", m)
+			sim := NewAlienSimulator(m, []*Alien{&alien1, &alien2}, 3, false)
+			sim.Sink = nil
+			sim.Movement = AlwaysMoveStrategy{}
+			sim.TrackOccupancy = val.trackOccupancy
+			s.Nil(sim.SimulateInvasion())
+			s.Equal(val.numDeadAliens, sim.NumDeadAliens)
+			s.Equal(val.cities, sim.Map.GetCitiesNames())
+		})
+	}
+}
+
+// TestSimulateInvasionPublishSnapshots tests that the published snapshots can be read while the simulation runs,
+// run it with -race to catch the simulator changing what the readers see
+func (s *SimulatorTestSuite) TestSimulateInvasionPublishSnapshots() {
+	m, _ := NewMapFromReader(strings.NewReader("a north=b east=c\nb south=a west=d\nc west=a north=d\nd east=b south=c\n"))
+	aliens := []*Alien{}
+	for i, city := range []string{"a", "b", "c", "d", "a", "c"} {
+		alien := NewAlien(i, fmt.Sprintf("alien%d", i), city, m)
+		aliens = append(aliens, &alien)
+	}
+	// aliens never fight, so the simulation runs long enough to be read
+	sim := NewAlienSimulator(m, aliens, 300, false)
+	sim.Sink = nil
+	sim.Seed = 3
+	sim.Rand = rand.New(rand.NewSource(sim.Seed))
+	sim.Collision = ThresholdCollision{Threshold: len(aliens) + 1}
+	sim.PublishSnapshots = true
+	s.Nil(sim.Snapshot())
+
+	done := make(chan struct{})
+	read := make(chan int)
+	go func() {
+		reads := 0
+		for {
+			select {
+			case <-done:
+				read <- reads
+				return
+			default:
+			}
+			snapshot := sim.Snapshot()
+			if snapshot == nil {
+				continue
+			}
+			for _, id := range snapshot.VertexIDs() {
+				city, _ := snapshot.Vertex(id)
+				for _, alien := range city.Aliens {
+					_ = alien.CurrentCityName
+					_ = alien.IsDead
+				}
+				snapshot.Neighbors(id)
+			}
+			reads++
+		}
+	}()
+	s.Nil(sim.SimulateInvasion())
+	close(done)
+	<-read
+
+	snapshot := sim.Snapshot()
+	s.NotNil(snapshot)
+	names := []string{}
+	for _, id := range snapshot.VertexIDs() {
+		names = append(names, string(id))
+	}
+	s.Equal(sim.Map.GetCitiesNames(), names)
+}
+
+// TestSimulateInvasionFightersDie tests that the aliens counted on a destroyed city are the fighters of its event and
+// the only ones dying, while the aliens that landed on it without counting outlive it on its ruins
+func (s *SimulatorTestSuite) TestSimulateInvasionFightersDie() {
+	m, _ := NewMapFromReader(strings.NewReader("a north=b\nb north=c\nc\nd west=c\n"))
+	aliens := []*Alien{}
+	for i, city := range []string{"a", "c", "d"} {
+		alien := NewAlien(i, fmt.Sprintf("alien%d", i), city, m)
+		aliens = append(aliens, &alien)
+	}
+	sim := NewAlienSimulator(m, aliens, 3, false)
+	sink := &recordingSink{}
+	sim.Sink = sink
+	sim.Movement = AlwaysMoveStrategy{}
+	s.Nil(sim.SimulateInvasion())
+
+	destroyed := sink.ofType(EventCityDestroyed)
+	s.Len(destroyed, 1)
+	s.Equal("c", destroyed[0].City)
+	s.Equal([]string{"alien2", "alien0"}, destroyed[0].Aliens)
+	s.Equal(destroyed[0].Aliens, destroyed[0].Dead)
+	s.Equal(len(destroyed[0].Aliens), sim.NumDeadAliens)
+	s.False(aliens[1].IsDead)
+	s.Equal([]string{"a", "b", "d"}, sim.Map.GetCitiesNames())
+}
+
+// TestSimulateInvasionDeadAliensDoNotCount tests that the event fighters account for every dead alien, aliens killed
+// on a city do not count on the cities they left before
+func (s *SimulatorTestSuite) TestSimulateInvasionDeadAliensDoNotCount() {
+	for seed := int64(0); seed < 20; seed++ {
+		m, _ := NewMapFromReader(strings.NewReader("a north=b east=c\nb south=a west=d\nc west=a north=d\nd east=b south=c\n"))
+		aliens := []*Alien{}
+		for i, city := range []string{"a", "b", "c", "d", "a"} {
+			alien := NewAlien(i, fmt.Sprintf("alien%d", i), city, m)
+			aliens = append(aliens, &alien)
+		}
+		sim := NewAlienSimulator(m, aliens, 10, false)
+		sink := &recordingSink{}
+		sim.Sink = sink
+		sim.Seed = seed
+		sim.Rand = rand.New(rand.NewSource(seed))
+		s.Nil(sim.SimulateInvasion())
+
+		fighters := 0
+		for _, event := range sink.ofType(EventCityDestroyed) {
+			s.Equal(event.Aliens, event.Dead, "seed %d", seed)
+			fighters += len(event.Aliens)
+		}
+		s.Equal(fighters, sim.NumDeadAliens, "seed %d", seed)
+	}
+}
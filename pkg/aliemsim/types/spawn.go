@@ -0,0 +1,299 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidSpawn = errors.New("Invalid spawn.")
+
+// SpawnPlacement is the city an alien lands on. Aliens without a name get a generated one.
+type SpawnPlacement struct {
+	Name string
+	City string
+}
+
+// label names the alien of the placement on errors, by its index when it has no name.
+func (p SpawnPlacement) label(index int) string {
+	if p.Name != "" {
+		return fmt.Sprintf("'%s'", p.Name)
+	}
+	return strconv.Itoa(index)
+}
+
+// SpawnStrategy decides the cities the aliens land on.
+type SpawnStrategy interface {
+	// Place returns the landing city of numAliens aliens. All randomness comes from rng.
+	Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error)
+}
+
+// UniformSpawn lands every alien on a random city. Several aliens can land on the same city. It is the default
+// strategy.
+type UniformSpawn struct{}
+
+func (UniformSpawn) Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error) {
+	cities := m.GetCitiesNames()
+	if numAliens > 0 && len(cities) == 0 {
+		return nil, fmt.Errorf("%w The map has no cities to land %d aliens on", ErrorInvalidSpawn, numAliens)
+	}
+	placements := []SpawnPlacement{}
+	for i := 0; i < numAliens; i++ {
+		placements = append(placements, SpawnPlacement{City: cities[rng.Intn(len(cities))]})
+	}
+	return placements, nil
+}
+
+// OnePerCitySpawn lands every alien on a different random city. Fails when there are more aliens than cities.
+type OnePerCitySpawn struct{}
+
+func (OnePerCitySpawn) Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error) {
+	cities := m.GetCitiesNames()
+	if numAliens > len(cities) {
+		return nil, fmt.Errorf("%w %d aliens cannot land one per city on %d cities", ErrorInvalidSpawn, numAliens, len(cities))
+	}
+	placements := []SpawnPlacement{}
+	for _, i := range rng.Perm(len(cities))[:numAliens] {
+		placements = append(placements, SpawnPlacement{City: cities[i]})
+	}
+	return placements, nil
+}
+
+// DegreeSpawn lands every alien on a random city with a probability proportional to the number of roads leaving and
+// reaching it. Cities without roads are never chosen, unless no city has roads and it works like UniformSpawn.
+type DegreeSpawn struct{}
+
+func (DegreeSpawn) Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error) {
+	cities := m.GetCitiesNames()
+	weights := make([]float64, len(cities))
+	total := 0.0
+	for i, name := range cities {
+		id := graph.VertexID(name)
+		weights[i] = float64(len(m.Graph.Neighbors(id)) + len(m.Graph.Incoming(id)))
+		total += weights[i]
+	}
+	if total == 0 {
+		return UniformSpawn{}.Place(numAliens, m, rng)
+	}
+	placements := []SpawnPlacement{}
+	for i := 0; i < numAliens; i++ {
+		target := rng.Float64() * total
+		chosen := len(cities) - 1
+		for j, w := range weights {
+			if target < w {
+				chosen = j
+				break
+			}
+			target -= w
+		}
+		placements = append(placements, SpawnPlacement{City: cities[chosen]})
+	}
+	return placements, nil
+}
+
+// ClusteredSpawn lands every alien near a landing site: a random site is picked for each alien, then a random city
+// at most Radius roads away from it. Sites are the named cities, or NumSites random cities when Sites is empty.
+type ClusteredSpawn struct {
+	Sites    []string
+	NumSites int
+	Radius   int
+}
+
+func (cs ClusteredSpawn) Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error) {
+	sites := cs.Sites
+	if len(sites) == 0 {
+		cities := m.GetCitiesNames()
+		numSites := cs.NumSites
+		if numSites <= 0 {
+			numSites = 1
+		}
+		if numSites > len(cities) {
+			return nil, fmt.Errorf("%w Cannot pick %d landing sites on %d cities", ErrorInvalidSpawn, numSites, len(cities))
+		}
+		for _, i := range rng.Perm(len(cities))[:numSites] {
+			sites = append(sites, cities[i])
+		}
+	}
+	clusters := make([][]string, len(sites))
+	for i, site := range sites {
+		if _, ok := m.Cities[site]; !ok {
+			return nil, fmt.Errorf("%w Landing site '%s': %v", ErrorInvalidSpawn, site, ErrorCityDoesNotExists)
+		}
+		clusters[i] = m.citiesAround(site, cs.Radius)
+	}
+	placements := []SpawnPlacement{}
+	for i := 0; i < numAliens; i++ {
+		cluster := clusters[rng.Intn(len(clusters))]
+		placements = append(placements, SpawnPlacement{City: cluster[rng.Intn(len(cluster))]})
+	}
+	return placements, nil
+}
+
+// citiesAround returns the city and the cities reachable from it following at most radius roads, closest first.
+// Roads are followed sorted by destination.
+func (m *Map[N, E]) citiesAround(cityName string, radius int) []string {
+	found := []string{}
+	m.Graph.BFS(graph.VertexID(cityName), func(v *graph.Vertex[City, Direction], depth int) bool {
+		if depth > radius {
+			return false
+		}
+		found = append(found, v.Data.Name)
+		return true
+	})
+	return found
+}
+
+// ExplicitSpawn lands the aliens on the cities of Placements in order. There need to be at least as many
+// placements as aliens.
+type ExplicitSpawn struct {
+	Placements []SpawnPlacement
+}
+
+func (es ExplicitSpawn) Place(numAliens int, m *Map[City, Direction], rng *rand.Rand) ([]SpawnPlacement, error) {
+	if numAliens > len(es.Placements) {
+		return nil, fmt.Errorf("%w %d aliens need a placement but there are only %d", ErrorInvalidSpawn, numAliens, len(es.Placements))
+	}
+	for _, placement := range es.Placements[:numAliens] {
+		if _, ok := m.Cities[placement.City]; !ok {
+			return nil, fmt.Errorf("%w Alien '%s' lands on '%s': %v", ErrorInvalidSpawn, placement.Name, placement.City, ErrorCityDoesNotExists)
+		}
+	}
+	return append([]SpawnPlacement{}, es.Placements[:numAliens]...), nil
+}
+
+// ReadSpawnPlacements reads placements with a line 'alien-name city' per alien. Blank lines and everything after a
+// '#' are skipped.
+func ReadSpawnPlacements(reader io.Reader) ([]SpawnPlacement, error) {
+	placements := []SpawnPlacement{}
+	names := map[string]int{}
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w line %d needs to have format 'alien-name city', got '%s'", ErrorInvalidSpawn, line, strings.TrimSpace(text))
+		}
+		if prev, ok := names[fields[0]]; ok {
+			return nil, fmt.Errorf("%w line %d: alien '%s' is already placed on line %d", ErrorInvalidSpawn, line, fields[0], prev)
+		}
+		names[fields[0]] = line
+		placements = append(placements, SpawnPlacement{Name: fields[0], City: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return placements, nil
+}
+
+var SpawnStrategyNames = []string{"uniform", "one-per-city", "degree", "clustered", "file"}
+
+// ParseSpawnStrategy creates a spawn strategy from its name. The clustered strategy takes the landing sites and an
+// optional radius, 1 by default, as 'clustered:Foo;Bar:2', or a number of random sites as 'clustered:3'. The 'file'
+// strategy needs to read its placements with ReadSpawnPlacements, and is not created here.
+func ParseSpawnStrategy(spec string) (SpawnStrategy, error) {
+	name, arg, hasArg := strings.Cut(spec, ":")
+	if hasArg && name != "clustered" {
+		return nil, fmt.Errorf("%w '%s' does not take parameters", ErrorInvalidSpawn, name)
+	}
+	switch name {
+	case "", "uniform":
+		return UniformSpawn{}, nil
+	case "one-per-city":
+		return OnePerCitySpawn{}, nil
+	case "degree":
+		return DegreeSpawn{}, nil
+	case "clustered":
+		cs := ClusteredSpawn{NumSites: 1, Radius: 1}
+		if !hasArg {
+			return cs, nil
+		}
+		sites, radius, hasRadius := strings.Cut(arg, ":")
+		if hasRadius {
+			r, err := strconv.Atoi(radius)
+			if err != nil || r < 0 {
+				return nil, fmt.Errorf("%w Radius '%s' needs to be a number of roads", ErrorInvalidSpawn, radius)
+			}
+			cs.Radius = r
+		}
+		if n, err := strconv.Atoi(sites); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("%w Clusters need at least 1 landing site, got %d", ErrorInvalidSpawn, n)
+			}
+			cs.NumSites = n
+			return cs, nil
+		}
+		cs.NumSites = 0
+		for _, site := range strings.Split(sites, ";") {
+			if site == "" {
+				return nil, fmt.Errorf("%w Landing sites need to have format 'Foo;Bar', got '%s'", ErrorInvalidSpawn, sites)
+			}
+			cs.Sites = append(cs.Sites, site)
+		}
+		return cs, nil
+	}
+	return nil, fmt.Errorf("%w '%s' needs to be one from %v", ErrorInvalidSpawn, spec, SpawnStrategyNames)
+}
+
+// SpawnCollision decides what happens with the aliens landing on the same city.
+type SpawnCollision string
+
+const (
+	// SpawnCoexist leaves the aliens together until another alien arrives to the city. It is the default policy.
+	SpawnCoexist SpawnCollision = "coexist"
+	// SpawnFight applies the collision policy of the simulation on every city with more than one alien at landing.
+	SpawnFight SpawnCollision = "fight"
+	// SpawnRelocate moves the aliens landing on an occupied city to a random free one.
+	SpawnRelocate SpawnCollision = "relocate"
+	// SpawnReject fails when aliens land on the same city.
+	SpawnReject SpawnCollision = "reject"
+)
+
+// ParseSpawnCollision returns the spawn collision policy with the given name.
+func ParseSpawnCollision(name string) (SpawnCollision, error) {
+	switch SpawnCollision(name) {
+	case SpawnCoexist, SpawnFight, SpawnRelocate, SpawnReject:
+		return SpawnCollision(name), nil
+	}
+	return "", fmt.Errorf("%w '%s' needs to be one from [%s %s %s %s]", ErrorInvalidSpawn, name, SpawnCoexist, SpawnFight, SpawnRelocate, SpawnReject)
+}
+
+// ResolveSpawnCollisions applies the relocate and reject policies to the placements. Other policies leave them as
+// they are. The random source is only used to relocate aliens.
+func ResolveSpawnCollisions(placements []SpawnPlacement, m *Map[City, Direction], policy SpawnCollision, rng *rand.Rand) ([]SpawnPlacement, error) {
+	if policy != SpawnRelocate && policy != SpawnReject {
+		return placements, nil
+	}
+	occupied := map[string]string{}
+	result := []SpawnPlacement{}
+	for i, placement := range placements {
+		if _, ok := occupied[placement.City]; ok {
+			if policy == SpawnReject {
+				return nil, fmt.Errorf("%w Alien %s lands on '%s' which is already occupied", ErrorInvalidSpawn, placement.label(i), placement.City)
+			}
+			free := []string{}
+			for _, name := range m.GetCitiesNames() {
+				if _, ok := occupied[name]; !ok {
+					free = append(free, name)
+				}
+			}
+			if len(free) == 0 {
+				return nil, fmt.Errorf("%w No free city left to relocate alien %s landing on '%s'", ErrorInvalidSpawn, placement.label(i), placement.City)
+			}
+			placement.City = free[rng.Intn(len(free))]
+		}
+		occupied[placement.City] = placement.Name
+		result = append(result, placement)
+	}
+	return result, nil
+}
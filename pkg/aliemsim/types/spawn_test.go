@@ -0,0 +1,224 @@
+package types
+
+import (
+	"github.com/stretchr/testify/suite"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type SpawnTestSuite struct {
+	suite.Suite
+}
+
+func TestSpawnTestSuite(t *testing.T) {
+	suite.Run(t, &SpawnTestSuite{})
+}
+
+// spawnMap is a line a-b-c-d with a hub on b, and an isolated city e.
+const spawnMap = "a east=b\nb west=a east=c north=hub\nc west=b east=d\nd west=c\nhub south=b\ne\n"
+
+func newSpawnMap() *Map[City, Direction] {
+	m, _ := NewMapFromReaderWithOptions(strings.NewReader(spawnMap), MapOptions{})
+	return m
+}
+
+func cityCounts(placements []SpawnPlacement) map[string]int {
+	counts := map[string]int{}
+	for _, p := range placements {
+		counts[p.City]++
+	}
+	return counts
+}
+
+func (s *SpawnTestSuite) TestParseSpawnStrategy() {
+	vals := []struct {
+		spec     string
+		strategy SpawnStrategy
+		err      error
+	}{
+		{spec: "", strategy: UniformSpawn{}},
+		{spec: "uniform", strategy: UniformSpawn{}},
+		{spec: "one-per-city", strategy: OnePerCitySpawn{}},
+		{spec: "degree", strategy: DegreeSpawn{}},
+		{spec: "clustered", strategy: ClusteredSpawn{NumSites: 1, Radius: 1}},
+		{spec: "clustered:3", strategy: ClusteredSpawn{NumSites: 3, Radius: 1}},
+		{spec: "clustered:2:0", strategy: ClusteredSpawn{NumSites: 2, Radius: 0}},
+		{spec: "clustered:Foo;Bar:2", strategy: ClusteredSpawn{Sites: []string{"Foo", "Bar"}, Radius: 2}},
+		{spec: "clustered:Foo", strategy: ClusteredSpawn{Sites: []string{"Foo"}, Radius: 1}},
+		{spec: "clustered:0", err: ErrorInvalidSpawn},
+		{spec: "clustered:Foo;:2", err: ErrorInvalidSpawn},
+		{spec: "clustered:Foo:far", err: ErrorInvalidSpawn},
+		{spec: "degree:2", err: ErrorInvalidSpawn},
+		{spec: "file", err: ErrorInvalidSpawn},
+		{spec: "everywhere", err: ErrorInvalidSpawn},
+	}
+	for _, val := range vals {
+		s.Run(val.spec, func() {
+			strategy, err := ParseSpawnStrategy(val.spec)
+			if val.err != nil {
+				s.ErrorIs(err, val.err)
+				return
+			}
+			s.Nil(err)
+			s.Equal(val.strategy, strategy)
+		})
+	}
+}
+
+func (s *SpawnTestSuite) TestUniformSpawn() {
+	m := newSpawnMap()
+	placements, err := UniformSpawn{}.Place(50, m, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	s.Len(placements, 50)
+	for _, p := range placements {
+		s.Contains(m.Cities, p.City)
+		s.Empty(p.Name)
+	}
+	again, _ := UniformSpawn{}.Place(50, m, rand.New(rand.NewSource(1)))
+	s.Equal(placements, again)
+
+	_, err = UniformSpawn{}.Place(1, &Map[City, Direction]{Cities: map[string]*City{}}, rand.New(rand.NewSource(1)))
+	s.ErrorIs(err, ErrorInvalidSpawn)
+}
+
+func (s *SpawnTestSuite) TestOnePerCitySpawn() {
+	m := newSpawnMap()
+	placements, err := OnePerCitySpawn{}.Place(6, m, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	counts := cityCounts(placements)
+	s.Len(counts, 6)
+
+	_, err = OnePerCitySpawn{}.Place(7, m, rand.New(rand.NewSource(1)))
+	s.ErrorIs(err, ErrorInvalidSpawn)
+}
+
+func (s *SpawnTestSuite) TestDegreeSpawn() {
+	m := newSpawnMap()
+	placements, err := DegreeSpawn{}.Place(2000, m, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	counts := cityCounts(placements)
+	s.Zero(counts["e"])
+	s.Greater(counts["b"], counts["a"]*2)
+	s.Greater(counts["c"], counts["d"])
+
+	isolated, _ := NewMapFromReader(strings.NewReader("x\ny\n"))
+	placements, err = DegreeSpawn{}.Place(10, isolated, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	s.Len(placements, 10)
+}
+
+func (s *SpawnTestSuite) TestClusteredSpawn() {
+	m := newSpawnMap()
+	s.Equal([]string{"a"}, m.citiesAround("a", 0))
+	s.Equal([]string{"a", "b"}, m.citiesAround("a", 1))
+	s.Equal([]string{"a", "b", "c", "hub"}, m.citiesAround("a", 2))
+
+	placements, err := ClusteredSpawn{Sites: []string{"a"}, Radius: 1}.Place(30, m, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	counts := cityCounts(placements)
+	s.Len(counts, 2)
+	s.Equal(30, counts["a"]+counts["b"])
+
+	placements, err = ClusteredSpawn{NumSites: 2, Radius: 0}.Place(30, m, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	s.Len(cityCounts(placements), 2)
+
+	_, err = ClusteredSpawn{Sites: []string{"nowhere"}}.Place(1, m, rand.New(rand.NewSource(1)))
+	s.ErrorIs(err, ErrorInvalidSpawn)
+	s.ErrorContains(err, "nowhere")
+	_, err = ClusteredSpawn{NumSites: 7}.Place(1, m, rand.New(rand.NewSource(1)))
+	s.ErrorIs(err, ErrorInvalidSpawn)
+}
+
+func (s *SpawnTestSuite) TestReadSpawnPlacements() {
+	placements, err := ReadSpawnPlacements(strings.NewReader("# landing plan\nzorg a\n\n  blip   hub # the hub\n"))
+	s.Nil(err)
+	s.Equal([]SpawnPlacement{{Name: "zorg", City: "a"}, {Name: "blip", City: "hub"}}, placements)
+
+	vals := []struct {
+		text string
+		err  string
+	}{
+		{text: "zorg\n", err: "line 1 needs to have format 'alien-name city', got 'zorg'"},
+		{text: "zorg a\n\nzorg b\n", err: "line 3: alien 'zorg' is already placed on line 1"},
+		{text: "zorg a b\n", err: "line 1"},
+	}
+	for _, val := range vals {
+		s.Run(val.text, func() {
+			_, err := ReadSpawnPlacements(strings.NewReader(val.text))
+			s.ErrorIs(err, ErrorInvalidSpawn)
+			s.ErrorContains(err, val.err)
+		})
+	}
+}
+
+func (s *SpawnTestSuite) TestExplicitSpawn() {
+	m := newSpawnMap()
+	explicit := ExplicitSpawn{Placements: []SpawnPlacement{{Name: "zorg", City: "a"}, {Name: "blip", City: "e"}}}
+	placements, err := explicit.Place(1, m, nil)
+	s.Nil(err)
+	s.Equal([]SpawnPlacement{{Name: "zorg", City: "a"}}, placements)
+
+	_, err = explicit.Place(3, m, nil)
+	s.ErrorIs(err, ErrorInvalidSpawn)
+
+	_, err = ExplicitSpawn{Placements: []SpawnPlacement{{Name: "zorg", City: "mars"}}}.Place(1, m, nil)
+	s.ErrorIs(err, ErrorInvalidSpawn)
+	s.ErrorContains(err, "mars")
+}
+
+func (s *SpawnTestSuite) TestParseSpawnCollision() {
+	for _, name := range []string{"coexist", "fight", "relocate", "reject"} {
+		policy, err := ParseSpawnCollision(name)
+		s.Nil(err)
+		s.Equal(SpawnCollision(name), policy)
+	}
+	_, err := ParseSpawnCollision("ignore")
+	s.ErrorIs(err, ErrorInvalidSpawn)
+}
+
+func (s *SpawnTestSuite) TestResolveSpawnCollisions() {
+	m := newSpawnMap()
+	placements := []SpawnPlacement{{City: "a"}, {City: "b"}, {City: "a"}, {City: "a"}}
+
+	for _, policy := range []SpawnCollision{"", SpawnCoexist, SpawnFight} {
+		result, err := ResolveSpawnCollisions(placements, m, policy, rand.New(rand.NewSource(1)))
+		s.Nil(err)
+		s.Equal(placements, result)
+	}
+
+	_, err := ResolveSpawnCollisions(placements, m, SpawnReject, nil)
+	s.ErrorIs(err, ErrorInvalidSpawn)
+	s.ErrorContains(err, "Alien 2 lands on 'a'")
+	named := []SpawnPlacement{{Name: "kang", City: "a"}, {Name: "kodos", City: "a"}}
+	_, err = ResolveSpawnCollisions(named, m, SpawnReject, nil)
+	s.ErrorContains(err, "Alien 'kodos' lands on 'a'")
+
+	result, err := ResolveSpawnCollisions(placements, m, SpawnRelocate, rand.New(rand.NewSource(1)))
+	s.Nil(err)
+	s.Equal(placements[:2], result[:2])
+	s.Len(cityCounts(result), 4)
+
+	_, err = ResolveSpawnCollisions(append(placements, SpawnPlacement{City: "a"}, SpawnPlacement{City: "a"}, SpawnPlacement{Name: "kang", City: "a"}), m, SpawnRelocate, rand.New(rand.NewSource(1)))
+	s.ErrorIs(err, ErrorInvalidSpawn)
+	s.ErrorContains(err, "relocate alien 'kang' landing on 'a'")
+}
+
+func (s *SpawnTestSuite) TestSpawnFight() {
+	sim, sink := buildLongRoadSim("a east=b\nb west=a\nc\n", []string{"a", "a", "b"})
+	sim.SpawnCollision = SpawnFight
+	sim.Movement = StayPut
+	s.Nil(sim.SimulateInvasion())
+	destroyed := sink.ofType(EventCityDestroyed)
+	s.Len(destroyed, 1)
+	s.Equal("a", destroyed[0].City)
+	s.Equal(0, destroyed[0].Iteration)
+	s.Equal(2, sim.NumDeadAliens)
+	s.NotContains(sim.Map.Cities, "a")
+
+	sim, sink = buildLongRoadSim("a east=b\nb west=a\n", []string{"a", "a"})
+	sim.Movement = StayPut
+	s.Nil(sim.SimulateInvasion())
+	s.Empty(sink.ofType(EventCityDestroyed))
+}
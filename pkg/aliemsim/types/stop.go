@@ -0,0 +1,50 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrorInvalidStopConditions = errors.New("Invalid stop conditions.")
+
+const (
+	StopCitiesDestroyed StopReason = "cities_destroyed"
+	StopAliensDead      StopReason = "aliens_dead"
+	StopCitiesLeft      StopReason = "cities_left"
+	StopAliensAlive     StopReason = "aliens_alive"
+)
+
+// StopConditions end the simulation before every alien is done. Conditions with a 0 value are disabled.
+type StopConditions struct {
+	// CitiesDestroyed stops once this number of cities is destroyed.
+	CitiesDestroyed int `json:"cities_destroyed,omitempty" yaml:"cities_destroyed,omitempty"`
+	// AliensDead stops once this number of aliens is dead.
+	AliensDead int `json:"aliens_dead,omitempty" yaml:"aliens_dead,omitempty"`
+	// CitiesLeft stops once this number of cities or less are left.
+	CitiesLeft int `json:"cities_left,omitempty" yaml:"cities_left,omitempty"`
+	// AliensAlive stops once this number of aliens or less are alive.
+	AliensAlive int `json:"aliens_alive,omitempty" yaml:"aliens_alive,omitempty"`
+}
+
+// Validate checks no condition is negative.
+func (sc StopConditions) Validate() error {
+	if sc.CitiesDestroyed < 0 || sc.AliensDead < 0 || sc.CitiesLeft < 0 || sc.AliensAlive < 0 {
+		return fmt.Errorf("%w Conditions cannot be negative, got %+v", ErrorInvalidStopConditions, sc)
+	}
+	return nil
+}
+
+// reached returns the reason of the first condition the simulation meets, in declaration order.
+func (sc StopConditions) reached(sim *AlienSimulator) (StopReason, bool) {
+	switch {
+	case sc.CitiesDestroyed > 0 && len(sim.DestroyedCities) >= sc.CitiesDestroyed:
+		return StopCitiesDestroyed, true
+	case sc.AliensDead > 0 && sim.NumDeadAliens >= sc.AliensDead:
+		return StopAliensDead, true
+	case sc.CitiesLeft > 0 && len(sim.Map.Cities) <= sc.CitiesLeft:
+		return StopCitiesLeft, true
+	case sc.AliensAlive > 0 && len(sim.Aliens)-sim.NumDeadAliens <= sc.AliensAlive:
+		return StopAliensAlive, true
+	}
+	return "", false
+}
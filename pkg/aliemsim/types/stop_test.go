@@ -0,0 +1,50 @@
+package types
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type StopTestSuite struct {
+	suite.Suite
+}
+
+func TestStopTestSuite(t *testing.T) {
+	suite.Run(t, &StopTestSuite{})
+}
+
+func (s *StopTestSuite) TestValidate() {
+	s.Nil(StopConditions{}.Validate())
+	s.Nil(StopConditions{CitiesDestroyed: 1, AliensAlive: 3}.Validate())
+	s.ErrorIs(StopConditions{CitiesLeft: -1}.Validate(), ErrorInvalidStopConditions)
+}
+
+func (s *StopTestSuite) TestStopConditions() {
+	// y is destroyed on iteration 0, and q once the alien on the long road arrives on iteration 2
+	const roads = "x east=y\ny\np east=q:3\nq\n"
+	vals := []struct {
+		name       string
+		stop       StopConditions
+		reason     StopReason
+		iterations int
+	}{
+		{name: "none", stop: StopConditions{}, reason: StopAllAliensDead, iterations: 2},
+		{name: "cities destroyed", stop: StopConditions{CitiesDestroyed: 1}, reason: StopCitiesDestroyed},
+		{name: "aliens dead", stop: StopConditions{AliensDead: 2}, reason: StopAliensDead},
+		{name: "cities left", stop: StopConditions{CitiesLeft: 3}, reason: StopCitiesLeft},
+		{name: "aliens alive", stop: StopConditions{AliensAlive: 2}, reason: StopAliensAlive},
+		{name: "first met", stop: StopConditions{AliensDead: 3, CitiesLeft: 3}, reason: StopCitiesLeft},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sim, sink := buildLongRoadSim(roads, []string{"x", "y", "p", "q"})
+			sim.Stop = val.stop
+			s.Nil(sim.SimulateInvasion())
+			s.Equal(val.reason, sim.StopReason)
+			s.Equal(val.iterations, sim.CurrentIteration)
+			ended := sink.ofType(EventSimulationEnded)
+			s.Len(ended, 1)
+			s.Equal(val.reason, ended[0].Reason)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var SweepFormats = []string{"csv", "json"}
+var ErrorInvalidRange = errors.New("Invalid range.")
+
+// ParseIntRange parses a single value like '10', an inclusive range like '1..200' or a range with step like
+// '1..200:10' into the list of values.
+func ParseIntRange(spec string) ([]int, error) {
+	bounds, stepStr, hasStep := strings.Cut(spec, ":")
+	startStr, endStr, isRange := strings.Cut(bounds, "..")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s' needs to have format 'start..end:step'", ErrorInvalidRange, spec)
+	}
+	if !isRange {
+		if hasStep {
+			return nil, fmt.Errorf("%w '%s' needs to have format 'start..end:step'", ErrorInvalidRange, spec)
+		}
+		return []int{start}, nil
+	}
+	end, err := strconv.Atoi(endStr)
+	if err != nil || end < start {
+		return nil, fmt.Errorf("%w '%s' needs an end not lower than its start", ErrorInvalidRange, spec)
+	}
+	step := 1
+	if hasStep {
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf("%w '%s' needs a positive step", ErrorInvalidRange, spec)
+		}
+	}
+	values := []int{}
+	for v := start; v <= end; v += step {
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// SweepRow holds the outcome metrics of a batch of runs with one combination of parameters.
+type SweepRow struct {
+	NumAliens     int `json:"aliens"`
+	MaxIterations int `json:"max_iterations"`
+	// Threshold is the collision threshold of the runs, 0 when the threshold was not swept.
+	Threshold int `json:"threshold,omitempty"`
+	BatchReport
+}
+
+// SweepReport is the table of outcome metrics of a parameter sweep.
+type SweepReport []SweepRow
+
+var sweepColumns = []string{
+	"aliens", "max_iterations", "threshold", "runs", "mean_iterations", "p50_iterations", "p90_iterations",
+	"p99_iterations", "alien_survival_rate", "city_survival_rate", "destroyed_fraction",
+}
+
+// Write writes the table into w on the given format. Format must be one of SweepFormats.
+func (sr SweepReport) Write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sr)
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(sweepColumns); err != nil {
+			return err
+		}
+		for _, row := range sr {
+			threshold := ""
+			if row.Threshold > 0 {
+				threshold = strconv.Itoa(row.Threshold)
+			}
+			record := []string{
+				strconv.Itoa(row.NumAliens),
+				strconv.Itoa(row.MaxIterations),
+				threshold,
+				strconv.Itoa(row.Runs),
+				strconv.FormatFloat(row.Iterations.Mean, 'f', 2, 64),
+				strconv.Itoa(row.Iterations.P50),
+				strconv.Itoa(row.Iterations.P90),
+				strconv.Itoa(row.Iterations.P99),
+				strconv.FormatFloat(row.AlienSurvivalRate, 'f', 4, 64),
+				strconv.FormatFloat(row.CitySurvivalRate, 'f', 4, 64),
+				strconv.FormatFloat(1-row.CitySurvivalRate, 'f', 4, 64),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return ErrorInvalidReportFormat
+}
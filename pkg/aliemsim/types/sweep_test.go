@@ -0,0 +1,68 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SweepTestSuite struct {
+	suite.Suite
+}
+
+func TestSweepTestSuite(t *testing.T) {
+	suite.Run(t, &SweepTestSuite{})
+}
+
+func (s *SweepTestSuite) TestParseIntRange() {
+	vals := []struct {
+		spec    string
+		values  []int
+		withErr bool
+	}{
+		{spec: "5", values: []int{5}},
+		{spec: "1..4", values: []int{1, 2, 3, 4}},
+		{spec: "1..200:50", values: []int{1, 51, 101, 151}},
+		{spec: "3..3:2", values: []int{3}},
+		{spec: "5:2", withErr: true},
+		{spec: "5..1", withErr: true},
+		{spec: "1..5:0", withErr: true},
+		{spec: "a..5", withErr: true},
+		{spec: "", withErr: true},
+	}
+	for _, val := range vals {
+		s.Run(val.spec, func() {
+			values, err := ParseIntRange(val.spec)
+			if val.withErr {
+				s.ErrorIs(err, ErrorInvalidRange)
+			} else {
+				s.Nil(err)
+				s.Equal(val.values, values)
+			}
+		})
+	}
+}
+
+func (s *SweepTestSuite) TestWrite() {
+	report := SweepReport{
+		{NumAliens: 2, MaxIterations: 10, Threshold: 3, BatchReport: BatchReport{Runs: 4, CitySurvivalRate: 0.25}},
+		{NumAliens: 4, MaxIterations: 10, BatchReport: BatchReport{Runs: 4, Iterations: IterationStats{Mean: 1.5, P90: 2}}},
+	}
+	var buf bytes.Buffer
+	s.Nil(report.Write(&buf, "csv"))
+	s.Equal("aliens,max_iterations,threshold,runs,mean_iterations,p50_iterations,p90_iterations,p99_iterations,alien_survival_rate,city_survival_rate,destroyed_fraction\n"+
+		"2,10,3,4,0.00,0,0,0,0.0000,0.2500,0.7500\n"+
+		"4,10,,4,1.50,0,2,0,0.0000,0.0000,1.0000\n", buf.String())
+
+	buf.Reset()
+	s.Nil(report.Write(&buf, "json"))
+	rows := []map[string]any{}
+	s.Nil(json.Unmarshal(buf.Bytes(), &rows))
+	s.Equal(2, len(rows))
+	s.Equal(float64(3), rows[0]["threshold"])
+	s.NotContains(rows[1], "threshold")
+	s.Equal(float64(4), rows[1]["runs"])
+
+	s.ErrorIs(report.Write(&buf, "yaml"), ErrorInvalidReportFormat)
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"errors"
+	"fmt"
+	"log"
+)
+
+var ErrorInvalidTickMode = errors.New("Invalid tick mode.")
+
+// TickMode decides how the moves of the aliens on an iteration are resolved.
+type TickMode string
+
+const (
+	// TickSequential moves aliens one at a time, every alien sees the moves of the aliens before it.
+	TickSequential TickMode = "sequential"
+	// TickSynchronous makes every alien choose its move from the same snapshot of the world and resolves them together.
+	TickSynchronous TickMode = "synchronous"
+)
+
+// ParseTickMode returns the tick mode with the given name.
+func ParseTickMode(name string) (TickMode, error) {
+	switch TickMode(name) {
+	case TickSequential, TickSynchronous:
+		return TickMode(name), nil
+	}
+	return "", fmt.Errorf("%w '%s' needs to be one from [%s %s]", ErrorInvalidTickMode, name, TickSequential, TickSynchronous)
+}
+
+// plannedMove is a road chosen by an alien on a synchronous tick.
+type plannedMove struct {
+	alien *Alien
+	road  *Road
+}
+
+// synchronousTick lets every alien choose its road before anybody moves. Aliens going opposite ways along the same
+// pair of cities meet on the road first, then all the survivors and the aliens reaching the end of long roads arrive
+// together and collisions are resolved once per city.
+func (sim *AlienSimulator) synchronousTick() error {
+	sim.roadEntries = map[graph.EdgeId]int{}
+	moves := []plannedMove{}
+	travelling := []*Alien{}
+	for _, alien := range sim.Aliens {
+		if sim.Verbose {
+			log.Printf("%s", alien.ToString())
+		}
+		if alien.Transit != nil && !alien.IsDead {
+			travelling = append(travelling, alien)
+			continue
+		}
+		if !sim.alienWillMove(alien) {
+			continue
+		}
+		road, err := sim.chooseRoad(alien)
+		if err != nil {
+			return err
+		}
+		if road != nil {
+			moves = append(moves, plannedMove{alien: alien, road: road})
+		}
+	}
+	moves = sim.resolveRoadFights(moves)
+
+	invaded := []*City{}
+	seen := map[*City]bool{}
+	for _, alien := range travelling {
+		if city := sim.arrive(alien); city != nil && !seen[city] {
+			seen[city] = true
+			invaded = append(invaded, city)
+		}
+	}
+	for _, move := range moves {
+		city := sim.travel(move.alien, move.road)
+		if city != nil && !seen[city] {
+			seen[city] = true
+			invaded = append(invaded, city)
+		}
+	}
+	for _, city := range invaded {
+		sim.collide(city)
+	}
+	return nil
+}
+
+// resolveRoadFights applies the collision policy to the aliens crossing each other along a pair of cities, and returns
+// the moves of the aliens that are still alive.
+func (sim *AlienSimulator) resolveRoadFights(moves []plannedMove) []plannedMove {
+	type cityPair struct {
+		a, b string
+	}
+	pairOf := func(move plannedMove) cityPair {
+		from, to := move.alien.CurrentCityName, move.road.To.Data.Name
+		if from > to {
+			return cityPair{to, from}
+		}
+		return cityPair{from, to}
+	}
+	groups := map[cityPair][]plannedMove{}
+	pairs := []cityPair{}
+	for _, move := range moves {
+		pair := pairOf(move)
+		if _, ok := groups[pair]; !ok {
+			pairs = append(pairs, pair)
+		}
+		groups[pair] = append(groups[pair], move)
+	}
+
+	for _, pair := range pairs {
+		group := groups[pair]
+		directions := map[string]bool{}
+		road := &City{}
+		for _, move := range group {
+			directions[move.alien.CurrentCityName] = true
+			road.Aliens = append(road.Aliens, move.alien)
+		}
+		if len(directions) < 2 {
+			continue
+		}
+		collision := sim.Collision.Resolve(road, sim.Rand)
+		killed := collision.Dead
+		if collision.DestroyCity {
+			killed = road.Aliens
+		}
+		if len(killed) == 0 {
+			continue
+		}
+		fighters := []string{}
+		for _, alien := range road.Aliens {
+			fighters = append(fighters, alien.Name)
+		}
+		dead := []string{}
+		for _, alien := range killed {
+			alien.IsDead = true
+			if city := sim.Map.graphCity(alien.CurrentCityName); city != nil {
+				city.removeAlien(alien)
+			}
+			dead = append(dead, alien.Name)
+		}
+		sim.emit(Event{Type: EventAliensFought, FromCity: pair.a, ToCity: pair.b, Aliens: fighters, Dead: dead})
+		sim.NumDeadAliens += len(dead)
+		sim.NumAliensCannotMove += len(dead)
+	}
+
+	alive := []plannedMove{}
+	for _, move := range moves {
+		if !move.alien.IsDead {
+			alive = append(alive, move)
+		}
+	}
+	return alive
+}
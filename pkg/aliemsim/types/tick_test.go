@@ -0,0 +1,146 @@
+package types
+
+import (
+	"alien-invasion-simulator/pkg/graph"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TickTestSuite struct {
+	suite.Suite
+}
+
+func TestTickTestSuite(t *testing.T) {
+	suite.Run(t, &TickTestSuite{})
+}
+
+func (s *TickTestSuite) TestParseTickMode() {
+	mode, err := ParseTickMode("sequential")
+	s.Nil(err)
+	s.Equal(TickSequential, mode)
+	mode, err = ParseTickMode("synchronous")
+	s.Nil(err)
+	s.Equal(TickSynchronous, mode)
+	_, err = ParseTickMode("parallel")
+	s.ErrorIs(err, ErrorInvalidTickMode)
+}
+
+// buildTickSim builds a simulator with aliens that always move on the given roads.
+func buildTickSim(roads [][2]string, alienCities []string, mode TickMode, policy CollisionPolicy) (AlienSimulator, *recordingSink) {
+	m := Map[City, Direction]{
+		Cities: CityStore{},
+		Graph:  graph.NewGraph[City, Direction](),
+	}
+	for _, road := range roads {
+		m.getOrCreateCity(road[0])
+		m.getOrCreateCity(road[1])
+		m.AddPath(road[0], road[1], "north")
+	}
+	aliens := []*Alien{}
+	for i, city := range alienCities {
+		alien := NewAlien(i, string(rune('a'+i)), city, &m)
+		aliens = append(aliens, &alien)
+	}
+	sink := &recordingSink{}
+	sim := NewAlienSimulator(&m, aliens, 1, false)
+	sim.Sink = sink
+	sim.Movement = AlwaysMoveStrategy{}
+	sim.Collision = policy
+	sim.TickMode = mode
+	sim.TrackOccupancy = true
+	return sim, sink
+}
+
+func (s *TickTestSuite) TestTickModes() {
+	vals := []struct {
+		name          string
+		roads         [][2]string
+		alienCities   []string
+		mode          TickMode
+		policy        CollisionPolicy
+		numDead       int
+		destroyed     []string
+		roadFights    int
+		finalCities   []string
+		numCitiesLeft int
+	}{
+		{
+			name:          "sequential alien runs into the next one",
+			roads:         [][2]string{{"x", "y"}, {"y", "z"}},
+			alienCities:   []string{"x", "y"},
+			mode:          TickSequential,
+			policy:        ThresholdCollision{Threshold: 2},
+			numDead:       2,
+			destroyed:     []string{"y"},
+			numCitiesLeft: 2,
+		},
+		{
+			name:          "synchronous aliens move together",
+			roads:         [][2]string{{"x", "y"}, {"y", "z"}},
+			alienCities:   []string{"x", "y"},
+			mode:          TickSynchronous,
+			policy:        ThresholdCollision{Threshold: 2},
+			numDead:       0,
+			finalCities:   []string{"y", "z"},
+			numCitiesLeft: 3,
+		},
+		{
+			name:          "synchronous aliens swapping meet on the road",
+			roads:         [][2]string{{"x", "y"}, {"y", "x"}},
+			alienCities:   []string{"x", "y"},
+			mode:          TickSynchronous,
+			policy:        ThresholdCollision{Threshold: 2},
+			numDead:       2,
+			roadFights:    1,
+			numCitiesLeft: 2,
+		},
+		{
+			name:          "synchronous aliens swapping under a high threshold",
+			roads:         [][2]string{{"x", "y"}, {"y", "x"}},
+			alienCities:   []string{"x", "y"},
+			mode:          TickSynchronous,
+			policy:        ThresholdCollision{Threshold: 3},
+			numDead:       0,
+			finalCities:   []string{"y", "x"},
+			numCitiesLeft: 2,
+		},
+		{
+			name:          "synchronous swap fight leaves a survivor",
+			roads:         [][2]string{{"x", "y"}, {"y", "x"}},
+			alienCities:   []string{"x", "y"},
+			mode:          TickSynchronous,
+			policy:        FightCollision{},
+			numDead:       1,
+			roadFights:    1,
+			numCitiesLeft: 2,
+		},
+		{
+			name:          "synchronous aliens arriving together to a city",
+			roads:         [][2]string{{"x", "c"}, {"y", "c"}, {"z", "c"}},
+			alienCities:   []string{"x", "y", "z"},
+			mode:          TickSynchronous,
+			policy:        ThresholdCollision{Threshold: 3},
+			numDead:       3,
+			destroyed:     []string{"c"},
+			numCitiesLeft: 3,
+		},
+	}
+	for _, val := range vals {
+		s.Run(val.name, func() {
+			sim, sink := buildTickSim(val.roads, val.alienCities, val.mode, val.policy)
+			s.Nil(sim.SimulateInvasion())
+			s.Equal(val.numDead, sim.NumDeadAliens)
+			s.Equal(val.numCitiesLeft, len(sim.Map.Cities))
+			destroyed := []string{}
+			for _, city := range sim.DestroyedCities {
+				destroyed = append(destroyed, city.Name)
+			}
+			s.ElementsMatch(val.destroyed, destroyed)
+			s.Equal(val.roadFights, len(sink.ofType(EventAliensFought)))
+			for i, city := range val.finalCities {
+				s.Equal(city, sim.Aliens[i].CurrentCityName)
+				s.True(sim.Map.graphCity(city).hasAlien(sim.Aliens[i]))
+			}
+		})
+	}
+}
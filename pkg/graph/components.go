@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"sort"
+)
+
+// sortedIDs returns the ids of the graph vertices sorted, so the algorithms below are deterministic.
+func (d *Graph[N, E]) sortedIDs() []VertexID {
+	ids := make([]VertexID, 0, len(d.nodes))
+	for id := range d.nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// sortComponents sorts the vertices of every component, and the components by their first vertex.
+func sortComponents(components [][]VertexID) [][]VertexID {
+	for _, c := range components {
+		sort.Slice(c, func(i, j int) bool { return c[i] < c[j] })
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// StronglyConnectedComponents returns the groups of vertices where every vertex reaches every other following the
+// edges, using Tarjan's algorithm. Every vertex is in exactly one component, vertices are sorted inside components
+// and components by their first vertex.
+func (d *Graph[N, E]) StronglyConnectedComponents() [][]VertexID {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	index := map[VertexID]int{}
+	lowLink := map[VertexID]int{}
+	onStack := map[VertexID]bool{}
+	stack := []VertexID{}
+	components := [][]VertexID{}
+	var connect func(v *Vertex[N, E])
+	connect = func(v *Vertex[N, E]) {
+		index[v.id] = len(index)
+		lowLink[v.id] = index[v.id]
+		stack = append(stack, v.id)
+		onStack[v.id] = true
+		for _, next := range sortedNeighbors(v) {
+			if _, visited := index[next.id]; !visited {
+				connect(next)
+				lowLink[v.id] = min(lowLink[v.id], lowLink[next.id])
+			} else if onStack[next.id] {
+				lowLink[v.id] = min(lowLink[v.id], index[next.id])
+			}
+		}
+		if lowLink[v.id] != index[v.id] {
+			return
+		}
+		component := []VertexID{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == v.id {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for _, id := range d.sortedIDs() {
+		if _, visited := index[id]; !visited {
+			connect(d.nodes[id])
+		}
+	}
+	return sortComponents(components)
+}
+
+// WeaklyConnectedComponents returns the groups of vertices connected when edges are followed in both directions,
+// sorted like StronglyConnectedComponents.
+func (d *Graph[N, E]) WeaklyConnectedComponents() [][]VertexID {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	seen := map[VertexID]bool{}
+	components := [][]VertexID{}
+	for _, id := range d.sortedIDs() {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		component := []VertexID{}
+		queue := []VertexID{id}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+			for _, next := range d.undirectedNeighbors(current) {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return sortComponents(components)
+}
+
+// undirectedNeighbors returns the sorted vertices linked to id by an edge on any direction.
+func (d *Graph[N, E]) undirectedNeighbors(id VertexID) []VertexID {
+	v := d.nodes[id]
+	linked := map[VertexID]bool{}
+	for to := range v.OutgoingEdges {
+		linked[to] = true
+	}
+	for from := range v.IncomingEdges {
+		linked[from] = true
+	}
+	delete(linked, id)
+	result := make([]VertexID, 0, len(linked))
+	for other := range linked {
+		result = append(result, other)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// ArticulationPoints returns the sorted vertices whose removal splits their weakly connected component. Edges are
+// followed in both directions.
+func (d *Graph[N, E]) ArticulationPoints() []VertexID {
+	points, _ := d.cutsOfUnderlyingGraph()
+	return points
+}
+
+// Bridges returns the links whose removal splits their weakly connected component. Edges are followed in both
+// directions, so a link is every edge between 2 vertices, and it is returned once with From < To. Bridges are sorted.
+func (d *Graph[N, E]) Bridges() []EdgeId {
+	_, bridges := d.cutsOfUnderlyingGraph()
+	return bridges
+}
+
+// cutsOfUnderlyingGraph finds the articulation points and bridges of the undirected graph with a link between every
+// pair of vertices with an edge, using Tarjan's lowpoints.
+func (d *Graph[N, E]) cutsOfUnderlyingGraph() ([]VertexID, []EdgeId) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	discovery := map[VertexID]int{}
+	low := map[VertexID]int{}
+	points := map[VertexID]bool{}
+	bridges := []EdgeId{}
+	var walk func(id, parent VertexID, isRoot bool)
+	walk = func(id, parent VertexID, isRoot bool) {
+		discovery[id] = len(discovery)
+		low[id] = discovery[id]
+		children := 0
+		for _, next := range d.undirectedNeighbors(id) {
+			if _, visited := discovery[next]; !visited {
+				children++
+				walk(next, id, false)
+				low[id] = min(low[id], low[next])
+				if !isRoot && low[next] >= discovery[id] {
+					points[id] = true
+				}
+				if low[next] > discovery[id] {
+					link := EdgeId{From: id, To: next}
+					if next < id {
+						link = EdgeId{From: next, To: id}
+					}
+					bridges = append(bridges, link)
+				}
+			} else if isRoot || next != parent {
+				low[id] = min(low[id], discovery[next])
+			}
+		}
+		if isRoot && children > 1 {
+			points[id] = true
+		}
+	}
+	for _, id := range d.sortedIDs() {
+		if _, visited := discovery[id]; !visited {
+			walk(id, "", true)
+		}
+	}
+	result := make([]VertexID, 0, len(points))
+	for id := range points {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	sort.Slice(bridges, func(i, j int) bool {
+		if bridges[i].From != bridges[j].From {
+			return bridges[i].From < bridges[j].From
+		}
+		return bridges[i].To < bridges[j].To
+	})
+	return result, bridges
+}
+
+// min returns the smallest of 2 ints.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
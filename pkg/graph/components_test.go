@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ComponentsTestSuite struct {
+	suite.Suite
+}
+
+func TestComponentsTestSuite(t *testing.T) {
+	suite.Run(t, &ComponentsTestSuite{})
+}
+
+// buildComponentsGraph builds the cycle a <-> b -> c -> a, the one way c -> d -> e <-> f, and the separate g <-> h.
+func buildComponentsGraph() *Graph[IdentifiableMock, string] {
+	vertices := []IdentifiableMock{}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		vertices = append(vertices, IdentifiableMock{id})
+	}
+	g, _ := NewGraphFrom(vertices, map[EdgeId]string{
+		{From: "a", To: "b"}: "",
+		{From: "b", To: "a"}: "",
+		{From: "b", To: "c"}: "",
+		{From: "c", To: "a"}: "",
+		{From: "c", To: "d"}: "",
+		{From: "d", To: "e"}: "",
+		{From: "e", To: "f"}: "",
+		{From: "f", To: "e"}: "",
+		{From: "g", To: "h"}: "",
+		{From: "h", To: "g"}: "",
+	})
+	return g
+}
+
+func (s *ComponentsTestSuite) TestStronglyConnectedComponents() {
+	g := buildComponentsGraph()
+	s.Equal([][]VertexID{{"a", "b", "c"}, {"d"}, {"e", "f"}, {"g", "h"}}, g.StronglyConnectedComponents())
+
+	empty := NewGraph[IdentifiableMock, string]()
+	s.Empty(empty.StronglyConnectedComponents())
+
+	_, err := g.AddEdge("f", "d", "")
+	s.Nil(err)
+	_, err = g.AddEdge("e", "c", "")
+	s.Nil(err)
+	s.Equal([][]VertexID{{"a", "b", "c", "d", "e", "f"}, {"g", "h"}}, g.StronglyConnectedComponents())
+}
+
+func (s *ComponentsTestSuite) TestWeaklyConnectedComponents() {
+	g := buildComponentsGraph()
+	s.Equal([][]VertexID{{"a", "b", "c", "d", "e", "f"}, {"g", "h"}}, g.WeaklyConnectedComponents())
+	g.AddVertex(IdentifiableMock{"z"})
+	s.Equal([][]VertexID{{"a", "b", "c", "d", "e", "f"}, {"g", "h"}, {"z"}}, g.WeaklyConnectedComponents())
+}
+
+func (s *ComponentsTestSuite) TestArticulationPointsAndBridges() {
+	g := buildComponentsGraph()
+	s.Equal([]VertexID{"c", "d", "e"}, g.ArticulationPoints())
+	s.Equal([]EdgeId{{From: "c", To: "d"}, {From: "d", To: "e"}, {From: "e", To: "f"}, {From: "g", To: "h"}}, g.Bridges())
+
+	// closing the loop c - d - e removes every cut on it
+	_, err := g.AddEdge("e", "c", "")
+	s.Nil(err)
+	s.Equal([]VertexID{"c", "e"}, g.ArticulationPoints())
+	s.Equal([]EdgeId{{From: "e", To: "f"}, {From: "g", To: "h"}}, g.Bridges())
+
+	empty := NewGraph[IdentifiableMock, string]()
+	s.Empty(empty.ArticulationPoints())
+	s.Empty(empty.Bridges())
+}
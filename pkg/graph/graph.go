@@ -45,17 +45,21 @@ type Graph[N Identifiable, E any] struct {
 	edges map[EdgeId]*Edge[N, E]
 	hash  Hash[N]
 	rw    sync.RWMutex
+	// shape holds the edges of the last snapshot, it is shared by the next snapshots until the graph changes.
+	shape   *snapshotShape[E]
+	shapeMu sync.Mutex
 }
 
-func NewGraph[N Identifiable, E any]() Graph[N, E] {
-	return Graph[N, E]{
+// NewGraph creates an empty graph. The zero value is an empty graph too, graphs are used through pointers so the
+// lock is never copied.
+func NewGraph[N Identifiable, E any]() *Graph[N, E] {
+	return &Graph[N, E]{
 		nodes: map[VertexID]*Vertex[N, E]{},
 		edges: map[EdgeId]*Edge[N, E]{},
-		rw:    sync.RWMutex{},
 	}
 }
 
-func NewGraphFrom[N Identifiable, E any](vertex []N, edges map[EdgeId]E) (Graph[N, E], error) {
+func NewGraphFrom[N Identifiable, E any](vertex []N, edges map[EdgeId]E) (*Graph[N, E], error) {
 	d := NewGraph[N, E]()
 	for _, n := range vertex {
 		d.addVertex(n)
@@ -69,6 +73,31 @@ func NewGraphFrom[N Identifiable, E any](vertex []N, edges map[EdgeId]E) (Graph[
 	return d, nil
 }
 
+// Clone returns a deep copy of the graph with new vertices and edges linked to each other like the originals. Vertex
+// and edge data are copied by value, so data holding pointers, maps or slices shares them with the original.
+func (d *Graph[N, E]) Clone() *Graph[N, E] {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	clone := NewGraph[N, E]()
+	clone.hash = d.hash
+	for id, node := range d.nodes {
+		clone.nodes[id] = &Vertex[N, E]{
+			id:            id,
+			Data:          node.Data,
+			IncomingEdges: make(map[VertexID]*Edge[N, E], len(node.IncomingEdges)),
+			OutgoingEdges: make(map[VertexID]*Edge[N, E], len(node.OutgoingEdges)),
+		}
+	}
+	for id, edge := range d.edges {
+		from, to := clone.nodes[id.From], clone.nodes[id.To]
+		cloneEdge := &Edge[N, E]{id: id, Data: edge.Data, From: from, To: to}
+		clone.edges[id] = cloneEdge
+		from.OutgoingEdges[id.To] = cloneEdge
+		to.IncomingEdges[id.From] = cloneEdge
+	}
+	return clone
+}
+
 func (d *Graph[N, E]) AddVertex(n N) VertexID {
 	d.rw.Lock()
 	defer d.rw.Unlock()
@@ -77,6 +106,10 @@ func (d *Graph[N, E]) AddVertex(n N) VertexID {
 
 func (d *Graph[N, E]) addVertex(n N) VertexID {
 	id := VertexID(n.ID())
+	if d.nodes == nil {
+		d.nodes = map[VertexID]*Vertex[N, E]{}
+	}
+	d.shape = nil
 	d.nodes[id] = &Vertex[N, E]{
 		id:            id,
 		Data:          n,
@@ -118,20 +151,25 @@ func (d *Graph[N, E]) RemoveVertex(v Vertex[N, E]) error {
 	return d.removeVertexByID(id)
 }
 
+// removeVertexByID removes the vertex and its edges. Edges are found through the adjacency maps of the vertex, so
+// the cost follows its degree and not the number of edges of the graph.
 func (d *Graph[N, E]) removeVertexByID(id VertexID) error {
 	node := d.getVertexByID(id)
 	if node == nil {
 		return fmt.Errorf("Vertex %v not found to remove", id)
 	}
-	for edge, _ := range d.edges {
-		if edge.From == id || edge.To == id {
-			err := d.removeEdge(edge.From, edge.To)
-			if err != nil {
-				return err
-			}
+	for to := range node.OutgoingEdges {
+		if err := d.removeEdge(id, to); err != nil {
+			return err
+		}
+	}
+	for from := range node.IncomingEdges {
+		if err := d.removeEdge(from, id); err != nil {
+			return err
 		}
 	}
 	delete(d.nodes, id)
+	d.shape = nil
 	return nil
 }
 
@@ -159,7 +197,11 @@ func (d *Graph[N, E]) addEdge(from, to VertexID, value E) (EdgeId, error) {
 
 	fromNode.OutgoingEdges[to] = &edge
 	toNode.IncomingEdges[from] = &edge
+	if d.edges == nil {
+		d.edges = map[EdgeId]*Edge[N, E]{}
+	}
 	d.edges[id] = &edge
+	d.shape = nil
 
 	return id, nil
 }
@@ -194,14 +236,83 @@ func (d *Graph[N, E]) removeEdge(from, to VertexID) error {
 	delete(d.edges, EdgeId{from, to})
 	delete(fromNode.OutgoingEdges, to)
 	delete(toNode.IncomingEdges, from)
+	d.shape = nil
 
 	return nil
 }
 
+// GetEdges returns a copy of the edges map taken under the read lock. Edges are shared with the graph.
 func (d *Graph[N, E]) GetEdges() map[EdgeId]*Edge[N, E] {
-	return d.edges
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	edges := make(map[EdgeId]*Edge[N, E], len(d.edges))
+	for id, edge := range d.edges {
+		edges[id] = edge
+	}
+	return edges
 }
 
+// GetNodes returns a copy of the vertices map taken under the read lock. Vertices are shared with the graph.
 func (d *Graph[N, E]) GetNodes() map[VertexID]*Vertex[N, E] {
-	return d.nodes
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	nodes := make(map[VertexID]*Vertex[N, E], len(d.nodes))
+	for id, node := range d.nodes {
+		nodes[id] = node
+	}
+	return nodes
+}
+
+// Vertices calls fn with every vertex in no particular order until it returns false. The graph is read locked
+// meanwhile, so fn cannot change it.
+func (d *Graph[N, E]) Vertices(fn func(*Vertex[N, E]) bool) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	for _, node := range d.nodes {
+		if !fn(node) {
+			return
+		}
+	}
+}
+
+// Edges calls fn with every edge in no particular order until it returns false. The graph is read locked meanwhile,
+// so fn cannot change it.
+func (d *Graph[N, E]) Edges(fn func(*Edge[N, E]) bool) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	for _, edge := range d.edges {
+		if !fn(edge) {
+			return
+		}
+	}
+}
+
+// Neighbors returns a copy of the outgoing edges of a vertex by destination, or nil when the vertex does not exist.
+func (d *Graph[N, E]) Neighbors(id VertexID) map[VertexID]*Edge[N, E] {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	node := d.getVertexByID(id)
+	if node == nil {
+		return nil
+	}
+	neighbors := make(map[VertexID]*Edge[N, E], len(node.OutgoingEdges))
+	for to, edge := range node.OutgoingEdges {
+		neighbors[to] = edge
+	}
+	return neighbors
+}
+
+// Incoming returns a copy of the incoming edges of a vertex by origin, or nil when the vertex does not exist.
+func (d *Graph[N, E]) Incoming(id VertexID) map[VertexID]*Edge[N, E] {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	node := d.getVertexByID(id)
+	if node == nil {
+		return nil
+	}
+	incoming := make(map[VertexID]*Edge[N, E], len(node.IncomingEdges))
+	for from, edge := range node.IncomingEdges {
+		incoming[from] = edge
+	}
+	return incoming
 }
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -41,6 +42,20 @@ func (s *GraphTestSuite) TestNewGraph() {
 	s.NotNil(g.GetEdges())
 }
 
+// TestZeroGraph tests that the zero value is an empty graph ready to use
+func (s *GraphTestSuite) TestZeroGraph() {
+	var g Graph[IdentifiableMock, string]
+	s.Empty(g.GetNodes())
+	s.Nil(g.GetVertexByID("a"))
+	s.Zero(g.Snapshot().NumVertices())
+	g.AddVertex(IdentifiableMock{"a"})
+	g.AddVertex(IdentifiableMock{"b"})
+	_, err := g.AddEdge("a", "b", "north")
+	s.Nil(err)
+	s.Len(g.GetEdges(), 1)
+	s.Equal([]VertexID{"a", "b"}, g.Clone().Snapshot().VertexIDs())
+}
+
 func (s *GraphTestSuite) TestNewGraphFrom() {
 	vals := []struct {
 		name    string
@@ -210,7 +225,7 @@ func (s *GraphTestSuite) TestRemoveVertex() {
 		name  string
 		id    string
 		found bool
-		g     Graph[IdentifiableMock, string]
+		g     *Graph[IdentifiableMock, string]
 		edges []Edge[IdentifiableMock, string]
 	}{
 		{
@@ -442,3 +457,186 @@ func (s *GraphTestSuite) TestRemoveEdge() {
 		})
 	}
 }
+
+func (s *GraphTestSuite) TestRemoveVertexCleansNeighbors() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}, {"c"}}, map[EdgeId]string{
+		{From: "a", To: "b"}: "",
+		{From: "b", To: "a"}: "",
+		{From: "c", To: "a"}: "",
+		{From: "b", To: "c"}: "",
+		{From: "a", To: "a"}: "",
+	})
+	s.Nil(err)
+	s.Nil(g.RemoveVertexByID("a"))
+	s.Nil(g.GetVertexByID("a"))
+	s.Equal(map[EdgeId]*Edge[IdentifiableMock, string]{{From: "b", To: "c"}: g.GetEdge("b", "c")}, g.GetEdges())
+	b, c := g.GetVertexByID("b"), g.GetVertexByID("c")
+	s.Empty(b.IncomingEdges)
+	s.Len(b.OutgoingEdges, 1)
+	s.Empty(c.OutgoingEdges)
+	s.Len(c.IncomingEdges, 1)
+}
+
+func (s *GraphTestSuite) TestIterators() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}, {"c"}}, map[EdgeId]string{
+		{From: "a", To: "b"}: "east",
+		{From: "a", To: "c"}: "north",
+		{From: "b", To: "c"}: "north",
+	})
+	s.Nil(err)
+	vertices := map[VertexID]bool{}
+	g.Vertices(func(v *Vertex[IdentifiableMock, string]) bool {
+		vertices[v.Id()] = true
+		return true
+	})
+	s.Equal(map[VertexID]bool{"a": true, "b": true, "c": true}, vertices)
+	count := 0
+	g.Vertices(func(v *Vertex[IdentifiableMock, string]) bool {
+		count++
+		return false
+	})
+	s.Equal(1, count)
+
+	edges := map[EdgeId]string{}
+	g.Edges(func(e *Edge[IdentifiableMock, string]) bool {
+		edges[e.Id()] = e.Data
+		return true
+	})
+	s.Equal(map[EdgeId]string{{From: "a", To: "b"}: "east", {From: "a", To: "c"}: "north", {From: "b", To: "c"}: "north"}, edges)
+
+	neighbors := g.Neighbors("a")
+	s.Equal(map[VertexID]*Edge[IdentifiableMock, string]{"b": g.GetEdge("a", "b"), "c": g.GetEdge("a", "c")}, neighbors)
+	delete(neighbors, "b")
+	s.Len(g.GetVertexByID("a").OutgoingEdges, 2)
+	s.Empty(g.Neighbors("c"))
+	s.NotNil(g.Neighbors("c"))
+	s.Nil(g.Neighbors("z"))
+
+	incoming := g.Incoming("c")
+	s.Equal(map[VertexID]*Edge[IdentifiableMock, string]{"a": g.GetEdge("a", "c"), "b": g.GetEdge("b", "c")}, incoming)
+	delete(incoming, "a")
+	s.Len(g.GetVertexByID("c").IncomingEdges, 2)
+	s.Empty(g.Incoming("a"))
+	s.Nil(g.Incoming("z"))
+
+	// the accessors return copies
+	delete(g.GetNodes(), "a")
+	delete(g.GetEdges(), EdgeId{From: "a", To: "b"})
+	s.NotNil(g.GetVertexByID("a"))
+	s.NotNil(g.GetEdge("a", "b"))
+}
+
+func (s *GraphTestSuite) TestConcurrentReaders() {
+	g := benchmarkGraph(50, 3)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 50; i++ {
+			g.RemoveVertexByID(VertexID(fmt.Sprintf("v%d", i)))
+		}
+		done <- true
+	}()
+	for writing := true; writing; {
+		select {
+		case <-done:
+			writing = false
+		default:
+			g.Vertices(func(v *Vertex[IdentifiableMock, string]) bool { return true })
+			g.Edges(func(e *Edge[IdentifiableMock, string]) bool { return true })
+			g.Neighbors("v49")
+			g.Snapshot()
+		}
+	}
+	s.Empty(g.GetNodes())
+	s.Empty(g.GetEdges())
+}
+
+func (s *GraphTestSuite) TestClone() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}, {"c"}}, map[EdgeId]string{
+		{From: "a", To: "b"}: "east",
+		{From: "b", To: "a"}: "west",
+		{From: "b", To: "c"}: "north",
+	})
+	s.Nil(err)
+	clone := g.Clone()
+	s.Equal(g.Snapshot(), clone.Snapshot())
+	for id, edge := range clone.GetEdges() {
+		s.NotSame(g.GetEdge(id.From, id.To), edge)
+		s.Same(clone.GetVertexByID(id.From), edge.From)
+		s.Same(clone.GetVertexByID(id.To), edge.To)
+		s.Same(edge, edge.From.OutgoingEdges[id.To])
+		s.Same(edge, edge.To.IncomingEdges[id.From])
+	}
+	for id, vertex := range clone.GetNodes() {
+		s.NotSame(g.GetVertexByID(id), vertex)
+	}
+
+	// changing the clone does not change the original and the other way around
+	s.Nil(clone.RemoveVertexByID("b"))
+	clone.GetVertexByID("a").Data = IdentifiableMock{"z"}
+	_, err = g.AddEdge("c", "a", "south")
+	s.Nil(err)
+	s.Equal([]VertexID{"a", "b", "c"}, g.Snapshot().VertexIDs())
+	s.Len(g.GetEdges(), 4)
+	s.Equal(IdentifiableMock{"a"}, g.GetVertexByID("a").Data)
+	s.Empty(clone.GetEdges())
+	s.Nil(clone.GetEdge("c", "a"))
+}
+
+// benchmarkGraph builds a graph of numVertices vertices where each vertex has edges to the next degree vertices.
+func benchmarkGraph(numVertices, degree int) *Graph[IdentifiableMock, string] {
+	g := NewGraph[IdentifiableMock, string]()
+	for i := 0; i < numVertices; i++ {
+		g.AddVertex(IdentifiableMock{fmt.Sprintf("v%d", i)})
+	}
+	for i := 0; i < numVertices; i++ {
+		for j := 1; j <= degree; j++ {
+			g.AddEdge(VertexID(fmt.Sprintf("v%d", i)), VertexID(fmt.Sprintf("v%d", (i+j)%numVertices)), "")
+		}
+	}
+	return g
+}
+
+// benchmarkRemoveVertex removes a vertex and adds it back with its edges on every iteration, both follow its degree.
+func benchmarkRemoveVertex(b *testing.B, numVertices, degree int) {
+	g := benchmarkGraph(numVertices, degree)
+	vertex := g.GetVertexByID("v0")
+	data := vertex.Data
+	outgoing, incoming := []VertexID{}, []VertexID{}
+	for to := range vertex.OutgoingEdges {
+		outgoing = append(outgoing, to)
+	}
+	for from := range vertex.IncomingEdges {
+		incoming = append(incoming, from)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := g.RemoveVertexByID("v0"); err != nil {
+			b.Fatal(err)
+		}
+		g.AddVertex(data)
+		for _, to := range outgoing {
+			g.AddEdge("v0", to, "")
+		}
+		for _, from := range incoming {
+			g.AddEdge(from, "v0", "")
+		}
+	}
+}
+
+// BenchmarkRemoveVertexEdges keeps the degree and grows the number of edges, the time per removal stays flat.
+func BenchmarkRemoveVertexEdges(b *testing.B) {
+	for _, numVertices := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("edges=%d", numVertices*4), func(b *testing.B) {
+			benchmarkRemoveVertex(b, numVertices, 4)
+		})
+	}
+}
+
+// BenchmarkRemoveVertexDegree keeps the graph size and grows the degree, the time per removal grows with it.
+func BenchmarkRemoveVertexDegree(b *testing.B) {
+	for _, degree := range []int{2, 20, 200} {
+		b.Run(fmt.Sprintf("degree=%d", degree*2), func(b *testing.B) {
+			benchmarkRemoveVertex(b, 20000, degree)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrorVertexNotFound = errors.New("Vertex not found.")
+var ErrorNoPath = errors.New("No path between vertices.")
+var ErrorNegativeWeight = errors.New("Edge weights cannot be negative.")
+
+// Weighted is implemented by edge data that knows its own weight.
+type Weighted interface {
+	Weight() float64
+}
+
+// WeightFunc returns the weight of an edge. Weights cannot be negative.
+type WeightFunc[N Identifiable, E any] func(*Edge[N, E]) float64
+
+// Visitor receives each vertex of a traversal with its depth, the number of edges from the start. The traversal
+// stops when it returns false. The graph is read locked during the traversal, so visitors cannot change it.
+type Visitor[N Identifiable, E any] func(v *Vertex[N, E], depth int) bool
+
+// sortedNeighbors returns the vertices reached by the outgoing edges of v sorted by id, so traversals are
+// deterministic.
+func sortedNeighbors[N Identifiable, E any](v *Vertex[N, E]) []*Vertex[N, E] {
+	neighbors := make([]*Vertex[N, E], 0, len(v.OutgoingEdges))
+	for _, edge := range v.OutgoingEdges {
+		neighbors = append(neighbors, edge.To)
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].id < neighbors[j].id })
+	return neighbors
+}
+
+// BFS visits the vertices reachable from start in breadth first order, start first. Neighbors are visited by id.
+func (d *Graph[N, E]) BFS(start VertexID, visit Visitor[N, E]) error {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	_, err := d.bfs(start, visit)
+	return err
+}
+
+// bfs returns the vertex each visited vertex was reached from, start is missing.
+func (d *Graph[N, E]) bfs(start VertexID, visit Visitor[N, E]) (map[VertexID]VertexID, error) {
+	startNode := d.getVertexByID(start)
+	if startNode == nil {
+		return nil, fmt.Errorf("%w %v", ErrorVertexNotFound, start)
+	}
+	parents := map[VertexID]VertexID{}
+	depths := map[VertexID]int{start: 0}
+	queue := []*Vertex[N, E]{startNode}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !visit(current, depths[current.id]) {
+			return parents, nil
+		}
+		for _, next := range sortedNeighbors(current) {
+			if _, seen := depths[next.id]; !seen {
+				depths[next.id] = depths[current.id] + 1
+				parents[next.id] = current.id
+				queue = append(queue, next)
+			}
+		}
+	}
+	return parents, nil
+}
+
+// DFS visits the vertices reachable from start in depth first pre-order, start first. Neighbors are visited by id.
+func (d *Graph[N, E]) DFS(start VertexID, visit Visitor[N, E]) error {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	startNode := d.getVertexByID(start)
+	if startNode == nil {
+		return fmt.Errorf("%w %v", ErrorVertexNotFound, start)
+	}
+	seen := map[VertexID]bool{}
+	var walk func(v *Vertex[N, E], depth int) bool
+	walk = func(v *Vertex[N, E], depth int) bool {
+		seen[v.id] = true
+		if !visit(v, depth) {
+			return false
+		}
+		for _, next := range sortedNeighbors(v) {
+			if !seen[next.id] && !walk(next, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(startNode, 0)
+	return nil
+}
+
+// Reachable returns the set of vertices reachable from start following the edges. Start is always included, see
+// StrictSuccessors to leave it out.
+func (d *Graph[N, E]) Reachable(start VertexID) (map[VertexID]bool, error) {
+	reached := map[VertexID]bool{}
+	err := d.BFS(start, func(v *Vertex[N, E], depth int) bool {
+		reached[v.id] = true
+		return true
+	})
+	return reached, err
+}
+
+// StrictSuccessors returns the vertices reachable from start through at least one edge, sorted by id. Unlike
+// Reachable, start is only included when a cycle leads back to it.
+func (d *Graph[N, E]) StrictSuccessors(start VertexID) ([]VertexID, error) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	result := []VertexID{}
+	cycle := false
+	_, err := d.bfs(start, func(v *Vertex[N, E], depth int) bool {
+		if v.id != start {
+			result = append(result, v.id)
+		}
+		if _, ok := v.OutgoingEdges[start]; ok {
+			cycle = true
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		result = append(result, start)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result, nil
+}
+
+// CanReach tells if there is a path of edges from one vertex to the other. Every vertex reaches itself.
+func (d *Graph[N, E]) CanReach(from, to VertexID) (bool, error) {
+	found := false
+	err := d.BFS(from, func(v *Vertex[N, E], depth int) bool {
+		found = v.id == to
+		return !found
+	})
+	return found, err
+}
+
+// ShortestPath returns the path with the least edges between 2 vertices, both included. Among paths of the same
+// length the one visiting lower ids first is returned.
+func (d *Graph[N, E]) ShortestPath(from, to VertexID) ([]VertexID, error) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	if d.getVertexByID(to) == nil {
+		return nil, fmt.Errorf("%w %v", ErrorVertexNotFound, to)
+	}
+	found := false
+	parents, err := d.bfs(from, func(v *Vertex[N, E], depth int) bool {
+		found = v.id == to
+		return !found
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w %v -> %v", ErrorNoPath, from, to)
+	}
+	return buildPath(parents, from, to), nil
+}
+
+// buildPath follows the parents from to back to from.
+func buildPath(parents map[VertexID]VertexID, from, to VertexID) []VertexID {
+	path := []VertexID{to}
+	for current := to; current != from; {
+		current = parents[current]
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// EdgeWeight returns the weight of the edge data when it implements Weighted, or 1 otherwise. It is the weight used
+// by Dijkstra when none is given.
+func EdgeWeight[N Identifiable, E any](edge *Edge[N, E]) float64 {
+	if weighted, ok := any(edge.Data).(Weighted); ok {
+		return weighted.Weight()
+	}
+	return 1
+}
+
+// Dijkstra returns the path with the least total weight between 2 vertices, both included, and its weight. Uses
+// EdgeWeight when weight is nil. Fails on the first negative weight found.
+func (d *Graph[N, E]) Dijkstra(from, to VertexID, weight WeightFunc[N, E]) ([]VertexID, float64, error) {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	if weight == nil {
+		weight = EdgeWeight[N, E]
+	}
+	if d.getVertexByID(from) == nil {
+		return nil, 0, fmt.Errorf("%w %v", ErrorVertexNotFound, from)
+	}
+	if d.getVertexByID(to) == nil {
+		return nil, 0, fmt.Errorf("%w %v", ErrorVertexNotFound, to)
+	}
+	dist := map[VertexID]float64{from: 0}
+	parents := map[VertexID]VertexID{}
+	done := map[VertexID]bool{}
+	queue := &distanceQueue{{id: from}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(distanceItem)
+		if done[current.id] {
+			continue
+		}
+		if current.id == to {
+			return buildPath(parents, from, to), current.dist, nil
+		}
+		done[current.id] = true
+		for _, next := range sortedNeighbors(d.nodes[current.id]) {
+			w := weight(d.nodes[current.id].OutgoingEdges[next.id])
+			if w < 0 {
+				return nil, 0, fmt.Errorf("%w %v -> %v weighs %v", ErrorNegativeWeight, current.id, next.id, w)
+			}
+			if known, ok := dist[next.id]; !done[next.id] && (!ok || current.dist+w < known) {
+				dist[next.id] = current.dist + w
+				parents[next.id] = current.id
+				heap.Push(queue, distanceItem{id: next.id, dist: current.dist + w})
+			}
+		}
+	}
+	return nil, 0, fmt.Errorf("%w %v -> %v", ErrorNoPath, from, to)
+}
+
+// distanceItem is a vertex waiting on the Dijkstra queue with its distance from the start.
+type distanceItem struct {
+	id   VertexID
+	dist float64
+}
+
+// distanceQueue is a min heap of vertices by distance, then by id.
+type distanceQueue []distanceItem
+
+func (q distanceQueue) Len() int { return len(q) }
+func (q distanceQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	return q[i].id < q[j].id
+}
+func (q distanceQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x any)   { *q = append(*q, x.(distanceItem)) }
+func (q *distanceQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PathsTestSuite struct {
+	suite.Suite
+}
+
+func TestPathsTestSuite(t *testing.T) {
+	suite.Run(t, &PathsTestSuite{})
+}
+
+// weightMock is edge data with its own weight.
+type weightMock float64
+
+func (w weightMock) Weight() float64 {
+	return float64(w)
+}
+
+// buildPathsGraph builds a -> b -> d -> e, a -> c -> d, and the isolated f. The a -> b edge is heavy.
+func buildPathsGraph() *Graph[IdentifiableMock, weightMock] {
+	g, _ := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}, {"f"}}, map[EdgeId]weightMock{
+		{From: "a", To: "b"}: 5,
+		{From: "a", To: "c"}: 1,
+		{From: "b", To: "d"}: 1,
+		{From: "c", To: "d"}: 2,
+		{From: "d", To: "e"}: 1,
+	})
+	return g
+}
+
+func (s *PathsTestSuite) TestBFS() {
+	g := buildPathsGraph()
+	visited := []VertexID{}
+	depths := []int{}
+	s.Nil(g.BFS("a", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool {
+		visited = append(visited, v.Id())
+		depths = append(depths, depth)
+		return true
+	}))
+	s.Equal([]VertexID{"a", "b", "c", "d", "e"}, visited)
+	s.Equal([]int{0, 1, 1, 2, 3}, depths)
+
+	visited = []VertexID{}
+	s.Nil(g.BFS("a", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool {
+		visited = append(visited, v.Id())
+		return depth < 1
+	}))
+	s.Equal([]VertexID{"a", "b"}, visited)
+
+	s.ErrorIs(g.BFS("z", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool { return true }), ErrorVertexNotFound)
+}
+
+func (s *PathsTestSuite) TestDFS() {
+	g := buildPathsGraph()
+	visited := []VertexID{}
+	depths := []int{}
+	s.Nil(g.DFS("a", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool {
+		visited = append(visited, v.Id())
+		depths = append(depths, depth)
+		return true
+	}))
+	s.Equal([]VertexID{"a", "b", "d", "e", "c"}, visited)
+	s.Equal([]int{0, 1, 2, 3, 1}, depths)
+
+	visited = []VertexID{}
+	s.Nil(g.DFS("a", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool {
+		visited = append(visited, v.Id())
+		return v.Id() != "d"
+	}))
+	s.Equal([]VertexID{"a", "b", "d"}, visited)
+
+	s.ErrorIs(g.DFS("z", func(v *Vertex[IdentifiableMock, weightMock], depth int) bool { return true }), ErrorVertexNotFound)
+}
+
+func (s *PathsTestSuite) TestReachable() {
+	g := buildPathsGraph()
+	reached, err := g.Reachable("c")
+	s.Nil(err)
+	s.Equal(map[VertexID]bool{"c": true, "d": true, "e": true}, reached)
+
+	from, err := g.StrictSuccessors("a")
+	s.Nil(err)
+	s.Equal([]VertexID{"b", "c", "d", "e"}, from)
+	from, err = g.StrictSuccessors("f")
+	s.Nil(err)
+	s.Empty(from)
+	_, err = g.StrictSuccessors("z")
+	s.ErrorIs(err, ErrorVertexNotFound)
+
+	_, err = g.AddEdge("e", "a", 1)
+	s.Nil(err)
+	from, _ = g.StrictSuccessors("a")
+	s.Equal([]VertexID{"a", "b", "c", "d", "e"}, from)
+}
+
+func (s *PathsTestSuite) TestCanReach() {
+	g := buildPathsGraph()
+	vals := []struct {
+		from, to VertexID
+		reach    bool
+	}{
+		{from: "a", to: "e", reach: true},
+		{from: "a", to: "a", reach: true},
+		{from: "e", to: "a", reach: false},
+		{from: "a", to: "f", reach: false},
+	}
+	for _, val := range vals {
+		reach, err := g.CanReach(val.from, val.to)
+		s.Nil(err)
+		s.Equal(val.reach, reach, "%v -> %v", val.from, val.to)
+	}
+	_, err := g.CanReach("z", "a")
+	s.ErrorIs(err, ErrorVertexNotFound)
+}
+
+func (s *PathsTestSuite) TestShortestPath() {
+	g := buildPathsGraph()
+	path, err := g.ShortestPath("a", "e")
+	s.Nil(err)
+	s.Equal([]VertexID{"a", "b", "d", "e"}, path)
+	path, err = g.ShortestPath("c", "c")
+	s.Nil(err)
+	s.Equal([]VertexID{"c"}, path)
+
+	_, err = g.ShortestPath("e", "a")
+	s.ErrorIs(err, ErrorNoPath)
+	_, err = g.ShortestPath("a", "z")
+	s.ErrorIs(err, ErrorVertexNotFound)
+	_, err = g.ShortestPath("z", "a")
+	s.ErrorIs(err, ErrorVertexNotFound)
+}
+
+func (s *PathsTestSuite) TestDijkstra() {
+	g := buildPathsGraph()
+	path, weight, err := g.Dijkstra("a", "e", nil)
+	s.Nil(err)
+	s.Equal([]VertexID{"a", "c", "d", "e"}, path)
+	s.Equal(4.0, weight)
+
+	unit := func(e *Edge[IdentifiableMock, weightMock]) float64 { return 1 }
+	path, weight, err = g.Dijkstra("a", "e", unit)
+	s.Nil(err)
+	s.Equal([]VertexID{"a", "b", "d", "e"}, path)
+	s.Equal(3.0, weight)
+
+	path, weight, err = g.Dijkstra("d", "d", nil)
+	s.Nil(err)
+	s.Equal([]VertexID{"d"}, path)
+	s.Zero(weight)
+
+	_, _, err = g.Dijkstra("e", "a", nil)
+	s.ErrorIs(err, ErrorNoPath)
+	_, _, err = g.Dijkstra("z", "a", nil)
+	s.ErrorIs(err, ErrorVertexNotFound)
+	_, _, err = g.Dijkstra("a", "z", nil)
+	s.ErrorIs(err, ErrorVertexNotFound)
+
+	negative := func(e *Edge[IdentifiableMock, weightMock]) float64 { return -1 }
+	_, _, err = g.Dijkstra("a", "e", negative)
+	s.ErrorIs(err, ErrorNegativeWeight)
+}
+
+func (s *PathsTestSuite) TestEdgeWeight() {
+	g := NewGraph[IdentifiableMock, string]()
+	g.AddVertex(IdentifiableMock{"a"})
+	g.AddVertex(IdentifiableMock{"b"})
+	g.AddEdge("a", "b", "north")
+	s.Equal(1.0, EdgeWeight(g.GetEdge("a", "b")))
+	path, weight, err := g.Dijkstra("a", "b", nil)
+	s.Nil(err)
+	s.Equal([]VertexID{"a", "b"}, path)
+	s.Equal(1.0, weight)
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Snapshot is an immutable view of a graph at the time it was taken. Vertex and edge data are shallow copies unless
+// the snapshot is taken with SnapshotFunc, so data changed in place through pointers or slices it holds is seen by
+// the snapshot too.
+type Snapshot[N Identifiable, E any] struct {
+	vertices map[VertexID]N
+	*snapshotShape[E]
+}
+
+// snapshotShape is the edges of a snapshot. Snapshots taken while no vertex or edge is added or removed share it.
+type snapshotShape[E any] struct {
+	edges    map[EdgeId]E
+	outgoing map[VertexID][]VertexID
+}
+
+// Snapshot copies the vertex data under a single read lock. Readers can use the snapshot from any goroutine while
+// the graph keeps changing. The edges are only copied after the graph adds or removes a vertex or edge, so edge data
+// changed in place is not seen by snapshots taken later until then.
+func (d *Graph[N, E]) Snapshot() *Snapshot[N, E] {
+	return d.SnapshotFunc(nil)
+}
+
+// SnapshotFunc is Snapshot with copyVertex copying the data of every vertex, like the slices and maps it holds, so
+// the snapshot does not change when the data is changed in place. Vertex data is copied by value when copyVertex is
+// nil.
+func (d *Graph[N, E]) SnapshotFunc(copyVertex func(N) N) *Snapshot[N, E] {
+	d.rw.RLock()
+	defer d.rw.RUnlock()
+	snapshot := &Snapshot[N, E]{
+		vertices:      make(map[VertexID]N, len(d.nodes)),
+		snapshotShape: d.snapshotShape(),
+	}
+	for id, node := range d.nodes {
+		data := node.Data
+		if copyVertex != nil {
+			data = copyVertex(data)
+		}
+		snapshot.vertices[id] = data
+	}
+	return snapshot
+}
+
+// snapshotShape returns the edges of the last snapshot, or copies them when the graph changed since. The caller holds
+// the read lock, the shape lock keeps readers from building it at the same time.
+func (d *Graph[N, E]) snapshotShape() *snapshotShape[E] {
+	d.shapeMu.Lock()
+	defer d.shapeMu.Unlock()
+	if d.shape != nil {
+		return d.shape
+	}
+	shape := &snapshotShape[E]{
+		edges:    make(map[EdgeId]E, len(d.edges)),
+		outgoing: make(map[VertexID][]VertexID, len(d.nodes)),
+	}
+	for id, node := range d.nodes {
+		neighbors := make([]VertexID, 0, len(node.OutgoingEdges))
+		for to := range node.OutgoingEdges {
+			neighbors = append(neighbors, to)
+		}
+		sort.Slice(neighbors, func(i, j int) bool { return neighbors[i] < neighbors[j] })
+		shape.outgoing[id] = neighbors
+	}
+	for id, edge := range d.edges {
+		shape.edges[id] = edge.Data
+	}
+	d.shape = shape
+	return shape
+}
+
+// NumVertices returns the number of vertices on the snapshot.
+func (s *Snapshot[N, E]) NumVertices() int {
+	return len(s.vertices)
+}
+
+// NumEdges returns the number of edges on the snapshot.
+func (s *Snapshot[N, E]) NumEdges() int {
+	return len(s.edges)
+}
+
+// Vertex returns the data of a vertex and whether it exists.
+func (s *Snapshot[N, E]) Vertex(id VertexID) (N, bool) {
+	data, ok := s.vertices[id]
+	return data, ok
+}
+
+// Edge returns the data of the edge between 2 vertices and whether it exists.
+func (s *Snapshot[N, E]) Edge(from, to VertexID) (E, bool) {
+	data, ok := s.edges[EdgeId{From: from, To: to}]
+	return data, ok
+}
+
+// VertexIDs returns the ids of the vertices sorted.
+func (s *Snapshot[N, E]) VertexIDs() []VertexID {
+	ids := make([]VertexID, 0, len(s.vertices))
+	for id := range s.vertices {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// EdgeIDs returns the ids of the edges sorted by origin, then by destination.
+func (s *Snapshot[N, E]) EdgeIDs() []EdgeId {
+	ids := make([]EdgeId, 0, len(s.edges))
+	for id := range s.edges {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].From != ids[j].From {
+			return ids[i].From < ids[j].From
+		}
+		return ids[i].To < ids[j].To
+	})
+	return ids
+}
+
+// Neighbors returns the sorted destinations of the edges leaving a vertex, or nil when the vertex does not exist.
+func (s *Snapshot[N, E]) Neighbors(id VertexID) []VertexID {
+	neighbors, ok := s.outgoing[id]
+	if !ok {
+		return nil
+	}
+	return append([]VertexID{}, neighbors...)
+}
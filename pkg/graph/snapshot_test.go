@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, &SnapshotTestSuite{})
+}
+
+func (s *SnapshotTestSuite) TestSnapshot() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}, {"c"}}, map[EdgeId]string{
+		{From: "a", To: "c"}: "north",
+		{From: "a", To: "b"}: "east",
+		{From: "c", To: "a"}: "south",
+	})
+	s.Nil(err)
+	snapshot := g.Snapshot()
+	s.Equal(3, snapshot.NumVertices())
+	s.Equal(3, snapshot.NumEdges())
+	s.Equal([]VertexID{"a", "b", "c"}, snapshot.VertexIDs())
+	s.Equal([]EdgeId{{From: "a", To: "b"}, {From: "a", To: "c"}, {From: "c", To: "a"}}, snapshot.EdgeIDs())
+	s.Equal([]VertexID{"b", "c"}, snapshot.Neighbors("a"))
+	s.Empty(snapshot.Neighbors("b"))
+	s.Nil(snapshot.Neighbors("z"))
+
+	vertex, ok := snapshot.Vertex("b")
+	s.True(ok)
+	s.Equal(IdentifiableMock{"b"}, vertex)
+	_, ok = snapshot.Vertex("z")
+	s.False(ok)
+	edge, ok := snapshot.Edge("a", "c")
+	s.True(ok)
+	s.Equal("north", edge)
+	_, ok = snapshot.Edge("c", "b")
+	s.False(ok)
+
+	// later changes to the graph are not seen by the snapshot
+	s.Nil(g.RemoveVertexByID("a"))
+	_, err = g.AddEdge("b", "c", "north")
+	s.Nil(err)
+	s.Equal(3, snapshot.NumVertices())
+	s.Equal([]VertexID{"b", "c"}, snapshot.Neighbors("a"))
+	_, ok = snapshot.Edge("b", "c")
+	s.False(ok)
+	s.Equal([]VertexID{"b", "c"}, g.Snapshot().VertexIDs())
+
+	// neighbors are copied out of the snapshot
+	neighbors := snapshot.Neighbors("a")
+	neighbors[0] = "z"
+	s.Equal([]VertexID{"b", "c"}, snapshot.Neighbors("a"))
+}
+
+// TestSnapshotFunc tests that the vertex data of the snapshot is copied with the given func
+func (s *SnapshotTestSuite) TestSnapshotFunc() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}}, map[EdgeId]string{{From: "a", To: "b"}: "north"})
+	s.Nil(err)
+	copied := []string{}
+	snapshot := g.SnapshotFunc(func(mock IdentifiableMock) IdentifiableMock {
+		copied = append(copied, mock.id)
+		return IdentifiableMock{mock.id + "-copy"}
+	})
+	s.ElementsMatch([]string{"a", "b"}, copied)
+	vertex, ok := snapshot.Vertex("a")
+	s.True(ok)
+	s.Equal(IdentifiableMock{"a-copy"}, vertex)
+	s.Equal([]VertexID{"b"}, snapshot.Neighbors("a"))
+}
+
+func (s *SnapshotTestSuite) TestSnapshotEmpty() {
+	g := NewGraph[IdentifiableMock, string]()
+	snapshot := g.Snapshot()
+	s.Zero(snapshot.NumVertices())
+	s.Empty(snapshot.VertexIDs())
+	s.Empty(snapshot.EdgeIDs())
+}
+
+// TestSnapshotSharesEdges tests that snapshots share their edges until a vertex or edge is added or removed
+func (s *SnapshotTestSuite) TestSnapshotSharesEdges() {
+	g, err := NewGraphFrom([]IdentifiableMock{{"a"}, {"b"}}, map[EdgeId]string{{From: "a", To: "b"}: "north"})
+	s.Nil(err)
+	first := g.Snapshot()
+	s.Same(first.snapshotShape, g.Snapshot().snapshotShape)
+
+	_, err = g.AddEdge("b", "a", "south")
+	s.Nil(err)
+	second := g.Snapshot()
+	s.NotSame(first.snapshotShape, second.snapshotShape)
+	s.Equal(1, first.NumEdges())
+	s.Equal(2, second.NumEdges())
+
+	g.AddVertex(IdentifiableMock{"c"})
+	third := g.Snapshot()
+	s.NotSame(second.snapshotShape, third.snapshotShape)
+	s.Empty(third.Neighbors("c"))
+}